@@ -0,0 +1,102 @@
+package knapsack
+
+import (
+	"math"
+)
+
+// KnapsackApprox approximates the 0/1 Knapsack problem using a fully
+// polynomial-time approximation scheme (FPTAS). It returns the indices of a
+// set of items whose total value is guaranteed to be at least (1 - epsilon)
+// times the optimal value, in O(N^3 / epsilon) time regardless of capacity.
+//
+// Rather than indexing the DP table by capacity, it is indexed by value: for
+// each achievable total value we store the lightest set of items that
+// achieves it. That table would be as wide as the sum of all values, so the
+// values are first scaled down by a factor of epsilon * maxValue / N and
+// rounded down, which loses at most epsilon of the optimal value.
+//
+// ErrInvalidEpsilon is returned unless 0 < epsilon < 1.
+func KnapsackApprox(items []Packable, capacity int64, epsilon float64) ([]int64, error) {
+	if !(epsilon > 0 && epsilon < 1) {
+		return nil, ErrInvalidEpsilon
+	}
+
+	// Only items that could be packed on their own and add some value are worth
+	// considering. `candidates` holds their indices.
+	var candidates []int64
+	var maxValue int64 = 0
+	for i, item := range items {
+		if item.Weight() < 0 || item.Weight() > capacity || item.Value() <= 0 {
+			continue
+		}
+		candidates = append(candidates, int64(i))
+		if item.Value() > maxValue {
+			maxValue = item.Value()
+		}
+	}
+
+	if len(candidates) == 0 {
+		return []int64{}, nil
+	}
+
+	// If the scaling factor would be less than 1, the values are already small
+	// enough to solve exactly.
+	factor := epsilon * float64(maxValue) / float64(len(candidates))
+	if factor < 1 {
+		factor = 1
+	}
+
+	scaled := make([]int64, len(candidates))
+	var total int64 = 0
+	for i, index := range candidates {
+		scaled[i] = int64(float64(items[index].Value()) / factor)
+		total += scaled[i]
+	}
+
+	// `weights[i][v]` stores the minimum weight needed to reach a scaled value
+	// of exactly `v` using the first `i` candidates, or math.MaxInt64 if that
+	// value can't be reached. `keep` records whether candidate `i` is used.
+	weights := make([][]int64, len(candidates)+1)
+	keep := make([][]bool, len(candidates)+1)
+	for i := range weights {
+		weights[i] = make([]int64, total+1)
+		keep[i] = make([]bool, total+1)
+	}
+	for v := int64(1); v <= total; v++ {
+		weights[0][v] = math.MaxInt64
+	}
+
+	for i := 1; i <= len(candidates); i++ {
+		w := items[candidates[i-1]].Weight()
+
+		for v := int64(0); v <= total; v++ {
+			weights[i][v] = weights[i-1][v]
+
+			if scaled[i-1] > v || weights[i-1][v-scaled[i-1]] == math.MaxInt64 {
+				continue
+			}
+
+			if taken := weights[i-1][v-scaled[i-1]] + w; taken < weights[i][v] {
+				weights[i][v] = taken
+				keep[i][v] = true
+			}
+		}
+	}
+
+	// The best solution is the largest value that can be reached within
+	// capacity.
+	v := total
+	for weights[len(candidates)][v] > capacity {
+		v--
+	}
+
+	indices := []int64{}
+	for n := len(candidates); n > 0; n-- {
+		if keep[n][v] {
+			indices = append(indices, candidates[n-1])
+			v -= scaled[n-1]
+		}
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,61 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackApprox(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			12, 40,
+		},
+		TestKnapsackItem{
+			7, 27,
+		},
+		TestKnapsackItem{
+			11, 35,
+		},
+		TestKnapsackItem{
+			8, 29,
+		},
+		TestKnapsackItem{
+			9, 31,
+		},
+	}
+
+	_, optimal := KnapsackWithValue(items, 26)
+
+	for _, epsilon := range []float64{0.1, 0.5, 0.9} {
+		indices, err := KnapsackApprox(items, 26, epsilon)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var value, weight int64 = 0, 0
+		for _, i := range indices {
+			value += items[i].Value()
+			weight += items[i].Weight()
+		}
+
+		if weight > 26 {
+			t.Errorf("Expected a weight of at most %d, got %d", 26, weight)
+		}
+		if float64(value) < (1-epsilon)*float64(optimal) {
+			t.Errorf("Expected a value of at least %f, got %d", (1-epsilon)*float64(optimal), value)
+		}
+	}
+}
+
+func TestKnapsackApproxInvalidEpsilon(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+	}
+
+	for _, epsilon := range []float64{0, 1, -0.5, 2} {
+		if _, err := KnapsackApprox(items, 5, epsilon); err != ErrInvalidEpsilon {
+			t.Errorf("Expected %v, got %v", ErrInvalidEpsilon, err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package knapsack
+
+// DefaultAutoThreshold is the largest number of items for which KnapsackAuto
+// will consider avoiding the dense DP table.
+const DefaultAutoThreshold = 20
+
+// KnapsackAuto behaves exactly like Knapsack, returning the same indices, but
+// picks whichever way of solving suits the shape of the input. It's
+// KnapsackAutoThreshold with a threshold of DefaultAutoThreshold.
+func KnapsackAuto(items []Packable, capacity int64) []int64 {
+	return KnapsackAutoThreshold(items, capacity, DefaultAutoThreshold)
+}
+
+// KnapsackAutoThreshold behaves exactly like Knapsack, returning the same
+// indices, but when there are no more than `threshold` items and the capacity
+// is large compared to the number of items, it uses KnapsackSparse rather
+// than allocating Knapsack's dense table. N items can reach at most 2^N
+// distinct total weights, so once the capacity exceeds that, tracking only
+// the reachable weights needs less memory than a cell for every capacity, and
+// a handful of items can be packed into a capacity of billions.
+func KnapsackAutoThreshold(items []Packable, capacity int64, threshold int) []int64 {
+	// With 63 or more items, 2^N doesn't fit in an int64, so the capacity
+	// can't exceed it.
+	if len(items) <= threshold && len(items) < 63 && capacity >= int64(1)<<uint(len(items)) {
+		return KnapsackSparse(items, capacity)
+	}
+
+	return Knapsack(items, capacity)
+}
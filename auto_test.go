@@ -0,0 +1,53 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackAuto(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		for _, n := range []int{0, 3, 10, 25} {
+			items := RandomItems(n, 30, 20, seed)
+
+			for _, capacity := range []int64{0, 7, 100, 2000} {
+				expected := Knapsack(items, capacity)
+				if indices := KnapsackAuto(items, capacity); !reflect.DeepEqual(indices, expected) {
+					t.Errorf("Seed %d, %d items, capacity %d: expected %v, got %v", seed, n, capacity, expected, indices)
+				}
+			}
+		}
+	}
+}
+
+func TestKnapsackAutoHugeCapacity(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3e8, 5,
+		},
+		TestKnapsackItem{
+			2e8, 3,
+		},
+		TestKnapsackItem{
+			1e8, 4,
+		},
+	}
+
+	// Knapsack would need tens of gigabytes for its table.
+	indices := KnapsackAuto(items, 5e8)
+	if !reflect.DeepEqual(indices, []int64{2, 0}) {
+		t.Errorf("Expected %v, got %v", []int64{2, 0}, indices)
+	}
+}
+
+func TestKnapsackAutoThreshold(t *testing.T) {
+	items := RandomItems(8, 50, 20, 1)
+
+	// Whichever way it's solved, the result is the same.
+	expected := Knapsack(items, 1000)
+	for _, threshold := range []int{0, 8, 100} {
+		if indices := KnapsackAutoThreshold(items, 1000, threshold); !reflect.DeepEqual(indices, expected) {
+			t.Errorf("Threshold %d: expected %v, got %v", threshold, expected, indices)
+		}
+	}
+}
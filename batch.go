@@ -0,0 +1,49 @@
+package knapsack
+
+import (
+	"sync"
+)
+
+// A Problem is a single Knapsack to be packed by SolveBatch.
+type Problem struct {
+	Items    []Packable
+	Capacity int64
+}
+
+// SolveBatch solves many independent Knapsack problems concurrently, spread
+// across `workers` goroutines, and returns the Solution to each, as Solve
+// would return it, in the same order as `problems`. Each problem is solved on
+// a single goroutine, so unlike KnapsackParallel this speeds up solving many
+// small problems rather than one large one. A `workers` value less than 1 is
+// treated as 1.
+func SolveBatch(problems []Problem, workers int) []Solution {
+	if workers < 1 {
+		workers = 1
+	}
+
+	solutions := make([]Solution, len(problems))
+	next := make(chan int)
+
+	// Each worker writes only to the solutions for the problems it takes, so
+	// they never write to the same element.
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range next {
+				// Without options Solve can't fail.
+				solutions[i], _ = Solve(problems[i].Items, problems[i].Capacity)
+			}
+		}()
+	}
+
+	for i := range problems {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return solutions
+}
@@ -0,0 +1,36 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSolveBatch(t *testing.T) {
+	problems := make([]Problem, 20)
+	for i := range problems {
+		problems[i] = Problem{
+			Items:    RandomItems(10+i, 10, 20, int64(i)),
+			Capacity: int64(5 * i),
+		}
+	}
+
+	for _, workers := range []int{0, 1, 4, 50} {
+		solutions := SolveBatch(problems, workers)
+		if len(solutions) != len(problems) {
+			t.Fatalf("%d workers: expected %d solutions, got %d", workers, len(problems), len(solutions))
+		}
+
+		for i, p := range problems {
+			expected, _ := Solve(p.Items, p.Capacity)
+			if !reflect.DeepEqual(solutions[i], expected) {
+				t.Errorf("%d workers: problem %d: expected %v, got %v", workers, i, expected, solutions[i])
+			}
+		}
+	}
+}
+
+func TestSolveBatchEmpty(t *testing.T) {
+	if solutions := SolveBatch(nil, 4); len(solutions) != 0 {
+		t.Errorf("Expected no solutions, got %v", solutions)
+	}
+}
@@ -0,0 +1,78 @@
+package knapsack
+
+import (
+	"math/big"
+)
+
+// KnapsackBig solves the 0/1 Knapsack problem for a capacity that may be too
+// large to be represented by an int64, returning the indices of the items to
+// pack in ascending order.
+//
+// No solver that iterates over capacity could cope with such a capacity, so
+// this is built on the same branch-and-bound search as KnapsackBranchBound,
+// which only depends on the number of items. While the remaining capacity is
+// larger than an int64 every item is known to fit, and as soon as it falls
+// within range the search carries on with ordinary int64 arithmetic. The
+// search is exponential in the worst case, so it is only practical for a
+// modest number of items, up to a few dozen unless the bound prunes well.
+func KnapsackBig(items []Packable, capacity *big.Int) []int64 {
+	if capacity.Sign() < 0 {
+		return []int64{}
+	}
+	if capacity.IsInt64() {
+		return KnapsackBranchBound(items, capacity.Int64())
+	}
+
+	// Every item fits within the capacity on its own, so we can use the
+	// largest int64 to pick the candidates.
+	bb := newBranchBound(items, maxInt64.Int64())
+	bb.searchBig(0, new(big.Int).Set(capacity), 0)
+	return bb.indices()
+}
+
+// maxInt64 is the largest remaining capacity that branchBound.search can
+// handle.
+var maxInt64 = big.NewInt(1<<63 - 1)
+
+// searchBig behaves like search, but for a `remaining` capacity that may be
+// larger than an int64.
+func (bb *branchBound) searchBig(k int, remaining *big.Int, value int64) {
+	if remaining.IsInt64() {
+		bb.search(k, remaining.Int64(), value)
+		return
+	}
+
+	if value > bb.bestValue {
+		bb.bestValue = value
+		copy(bb.best, bb.current)
+	}
+
+	if k == len(bb.order) || bb.boundBig(k, remaining, value) <= bb.bestValue {
+		return
+	}
+
+	// The remaining capacity is larger than any item's weight, so the item
+	// always fits.
+	p := bb.items[bb.order[k]]
+	bb.current[k] = true
+	bb.searchBig(k+1, new(big.Int).Sub(remaining, big.NewInt(p.Weight())), value+p.Value())
+	bb.current[k] = false
+
+	bb.searchBig(k+1, remaining, value)
+}
+
+// boundBig behaves like bound, but for a `remaining` capacity that may be
+// larger than an int64.
+func (bb *branchBound) boundBig(k int, remaining *big.Int, value int64) int64 {
+	remaining = new(big.Int).Set(remaining)
+	for ; k < len(bb.order) && !remaining.IsInt64(); k++ {
+		p := bb.items[bb.order[k]]
+		remaining.Sub(remaining, big.NewInt(p.Weight()))
+		value += p.Value()
+	}
+
+	if k == len(bb.order) {
+		return value
+	}
+	return bb.bound(k, remaining.Int64(), value)
+}
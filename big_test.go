@@ -0,0 +1,65 @@
+package knapsack
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestKnapsackBig(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			math.MaxInt64, 5,
+		},
+		TestKnapsackItem{
+			math.MaxInt64 - 1, 3,
+		},
+		TestKnapsackItem{
+			math.MaxInt64 / 2, 4,
+		},
+		TestKnapsackItem{
+			2, 1,
+		},
+	}
+
+	// Twice the largest int64, which is enough for the first two items, or the
+	// first, third and fourth.
+	capacity := new(big.Int).Mul(big.NewInt(math.MaxInt64), big.NewInt(2))
+
+	var value int64 = 0
+	weight := new(big.Int)
+	for _, i := range KnapsackBig(items, capacity) {
+		value += items[i].Value()
+		weight.Add(weight, big.NewInt(items[i].Weight()))
+	}
+
+	if value != 10 {
+		t.Errorf("Expected %d, got %d", 10, value)
+	}
+	if weight.Cmp(capacity) > 0 {
+		t.Errorf("Expected a weight of at most %v, got %v", capacity, weight)
+	}
+}
+
+func TestKnapsackBigSmallCapacity(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	var value int64 = 0
+	for _, i := range KnapsackBig(items, big.NewInt(5)) {
+		value += items[i].Value()
+	}
+
+	if value != 9 {
+		t.Errorf("Expected %d, got %d", 9, value)
+	}
+}
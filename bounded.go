@@ -0,0 +1,89 @@
+package knapsack
+
+// BoundedKnapsack solves the bounded variant of the Knapsack problem, in which
+// up to `counts[i]` copies of `items[i]` may be packed. It returns the index of
+// the item for every unit packed, so an index appears once per copy, in
+// ascending order.
+//
+// Rather than treating every copy as its own item, each item is split into
+// copies of 1, 2, 4, ... units (plus whatever remains), which can be combined
+// to make any count up to the limit. This keeps the number of rows in the DP
+// table to O(N log K) rather than O(N K), where K is the largest count.
+//
+// ErrLengthMismatch is returned if `counts` does not have one entry per item.
+func BoundedKnapsack(items []Packable, counts []int64, capacity int64) ([]int64, error) {
+	if len(counts) != len(items) {
+		return nil, ErrLengthMismatch
+	}
+
+	// `chunks` holds the decomposed items, and `owner` and `units` record which
+	// item each chunk came from and how many copies of it the chunk represents.
+	var chunks []Packable
+	var owner, units []int64
+
+	for i, item := range items {
+		remaining := counts[i]
+		for size := int64(1); remaining > 0; size *= 2 {
+			if size > remaining {
+				size = remaining
+			}
+			chunks = append(chunks, chunk(item, size))
+			owner = append(owner, int64(i))
+			units = append(units, size)
+			remaining -= size
+		}
+	}
+
+	packed := make([]int64, len(items))
+	for _, i := range Knapsack(chunks, capacity) {
+		packed[owner[i]] += units[i]
+	}
+
+	indices := []int64{}
+	for i, n := range packed {
+		for ; n > 0; n-- {
+			indices = append(indices, int64(i))
+		}
+	}
+
+	return indices, nil
+}
+
+// chunk returns an item representing `size` copies of `p`.
+func chunk(p Packable, size int64) item {
+	return item{
+		weight: p.Weight() * size,
+		value:  p.Value() * size,
+	}
+}
+
+// A BoundedPackable item is a Packable that also knows how many copies of it
+// are available to pack.
+type BoundedPackable interface {
+	Packable
+	Quantity() int64
+}
+
+// KnapsackBounded solves the bounded Knapsack problem like BoundedKnapsack,
+// but takes the number of copies of each item from its Quantity method rather
+// than a separate slice. It returns a map of item index to the number of
+// copies to pack; items with no copies packed are left out of the map. An
+// item with a Quantity of 0 or less is unavailable and is never packed.
+func KnapsackBounded(items []BoundedPackable, capacity int64) map[int64]int64 {
+	packables := make([]Packable, len(items))
+	counts := make([]int64, len(items))
+	for i, item := range items {
+		packables[i] = item
+		counts[i] = item.Quantity()
+	}
+
+	// The counts have been built to match the items, so there's no error.
+	indices, _ := BoundedKnapsack(packables, counts, capacity)
+
+	copies := make(map[int64]int64)
+	for _, i := range indices {
+		copies[i]++
+	}
+
+	return copies
+}
@@ -0,0 +1,125 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestBoundedKnapsack(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+	counts := []int64{2, 5, 3}
+
+	indices, err := BoundedKnapsack(items, counts, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var value, weight int64 = 0, 0
+	used := make([]int64, len(items))
+	for _, i := range indices {
+		value += items[i].Value()
+		weight += items[i].Weight()
+		used[i]++
+	}
+
+	// All three copies of the third item, one of the first and two of the second.
+	if value != 23 {
+		t.Errorf("Expected %d, got %d", 23, value)
+	}
+	if weight > 10 {
+		t.Errorf("Expected a weight of at most %d, got %d", 10, weight)
+	}
+	for i := range used {
+		if used[i] > counts[i] {
+			t.Errorf("Expected at most %d copies of item %d, got %d", counts[i], i, used[i])
+		}
+	}
+}
+
+func TestBoundedKnapsackLengthMismatch(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+	}
+
+	if _, err := BoundedKnapsack(items, []int64{1, 2}, 10); err != ErrLengthMismatch {
+		t.Errorf("Expected %v, got %v", ErrLengthMismatch, err)
+	}
+}
+
+// TestBoundedItem is a BoundedPackable with a fixed quantity.
+type TestBoundedItem struct {
+	weight, value, quantity int64
+}
+
+func (i TestBoundedItem) Weight() int64 {
+	return i.weight
+}
+
+func (i TestBoundedItem) Value() int64 {
+	return i.value
+}
+
+func (i TestBoundedItem) Quantity() int64 {
+	return i.quantity
+}
+
+func TestKnapsackBounded(t *testing.T) {
+	items := []BoundedPackable{
+		TestBoundedItem{
+			3, 5, 2,
+		},
+		TestBoundedItem{
+			2, 3, 5,
+		},
+		TestBoundedItem{
+			1, 4, 3,
+		},
+	}
+
+	copies := KnapsackBounded(items, 10)
+
+	var value, weight int64
+	for i, n := range copies {
+		if n <= 0 || n > items[i].Quantity() {
+			t.Errorf("Item %d: expected between 1 and %d copies, got %d", i, items[i].Quantity(), n)
+		}
+		value += items[i].Value() * n
+		weight += items[i].Weight() * n
+	}
+	if value != 23 {
+		t.Errorf("Expected %d, got %d (%v)", 23, value, copies)
+	}
+	if weight > 10 {
+		t.Errorf("Expected a weight of at most %d, got %d", 10, weight)
+	}
+}
+
+func TestKnapsackBoundedUnavailable(t *testing.T) {
+	items := []BoundedPackable{
+		TestBoundedItem{
+			1, 100, 0,
+		},
+		TestBoundedItem{
+			1, 100, -1,
+		},
+		TestBoundedItem{
+			1, 1, 1,
+		},
+	}
+
+	copies := KnapsackBounded(items, 5)
+	if len(copies) != 1 || copies[2] != 1 {
+		t.Errorf("Expected map[2:1], got %v", copies)
+	}
+}
@@ -0,0 +1,187 @@
+package knapsack
+
+import (
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// KnapsackBranchBound solves the 0/1 Knapsack problem exactly using a
+// branch-and-bound search, returning the indices of the items to pack in
+// ascending order. It finds a solution of the same optimal value as Knapsack,
+// but rather than allocating a table with a column for every unit of
+// capacity, it searches the tree of decisions to pack or leave each item,
+// using memory proportional only to the number of items.
+//
+// To keep the search manageable, items are considered in order of descending
+// value density, and a branch is abandoned as soon as the value of the
+// fractional relaxation (in which the remaining capacity may be filled with
+// part of an item) can't beat the best solution found so far. The worst case
+// is still exponential in the number of items, but in practice this is far
+// faster than the DP when capacity is very large.
+func KnapsackBranchBound(items []Packable, capacity int64) []int64 {
+	bb := newBranchBound(items, capacity)
+	bb.search(0, capacity, 0)
+	return bb.indices()
+}
+
+// KnapsackDeadline behaves like KnapsackBranchBound, but stops searching
+// once `deadline` has elapsed. It returns the indices of the best items found,
+// along with whether the search finished and so proved them to be optimal.
+//
+// The search starts from the same packing as KnapsackGreedy and only ever
+// replaces it with a more valuable one, so stopping it at any point still
+// leaves a valid packing at least as good as KnapsackGreedy's.
+func KnapsackDeadline(items []Packable, capacity int64, deadline time.Duration) ([]int64, bool) {
+	bb := newBranchBound(items, capacity)
+	bb.deadline = time.Now().Add(deadline)
+	bb.search(0, capacity, 0)
+	return bb.indices(), !bb.stopped
+}
+
+// branchBound holds the state of a branch-and-bound search.
+type branchBound struct {
+	items []Packable
+
+	// order holds the indices of the items worth considering, in order of
+	// descending value density.
+	order []int64
+
+	// current and best record which positions in `order` are packed on the
+	// current branch and in the best solution found so far.
+	current   []bool
+	best      []bool
+	bestValue int64
+
+	// If deadline is set, the search is abandoned once it passes, setting
+	// stopped. nodes counts the branches searched so that the clock only needs
+	// to be checked occasionally.
+	deadline time.Time
+	stopped  bool
+	nodes    int
+}
+
+func newBranchBound(items []Packable, capacity int64) *branchBound {
+	// Items that can't fit or don't add any value never appear in an optimal
+	// solution, so leave them out of the search entirely.
+	var order []int64
+	for _, i := range SortByDensity(items) {
+		if w := items[i].Weight(); w >= 0 && w <= capacity && items[i].Value() > 0 {
+			order = append(order, i)
+		}
+	}
+
+	bb := &branchBound{
+		items:   items,
+		order:   order,
+		current: make([]bool, len(order)),
+		best:    make([]bool, len(order)),
+	}
+	bb.seed(capacity)
+	return bb
+}
+
+// seed sets the best solution to the one KnapsackGreedy would find: the
+// better of packing the items greedily by density and packing only the most
+// valuable item. That guarantees a good solution even if the search is
+// stopped straight away, and gives the bound something to prune against.
+func (bb *branchBound) seed(capacity int64) {
+	remaining := capacity
+	most := -1
+	for k, i := range bb.order {
+		p := bb.items[i]
+		if p.Weight() <= remaining {
+			bb.best[k] = true
+			bb.bestValue += p.Value()
+			remaining -= p.Weight()
+		}
+		if most == -1 || p.Value() > bb.items[bb.order[most]].Value() {
+			most = k
+		}
+	}
+
+	if most != -1 && bb.items[bb.order[most]].Value() > bb.bestValue {
+		for k := range bb.best {
+			bb.best[k] = k == most
+		}
+		bb.bestValue = bb.items[bb.order[most]].Value()
+	}
+}
+
+// search explores every way of packing the items from position `k` in
+// `order` onwards, given the `remaining` capacity and the `value` already
+// packed on this branch.
+func (bb *branchBound) search(k int, remaining int64, value int64) {
+	if bb.expired() {
+		return
+	}
+
+	if value > bb.bestValue {
+		bb.bestValue = value
+		copy(bb.best, bb.current)
+	}
+
+	if k == len(bb.order) || bb.bound(k, remaining, value) <= bb.bestValue {
+		return
+	}
+
+	// Try packing the item first, since the density ordering makes that the
+	// branch most likely to lead to a good solution and tighten the bound.
+	if p := bb.items[bb.order[k]]; p.Weight() <= remaining {
+		bb.current[k] = true
+		bb.search(k+1, remaining-p.Weight(), value+p.Value())
+		bb.current[k] = false
+	}
+
+	bb.search(k+1, remaining, value)
+}
+
+// expired reports whether the search has run past its deadline.
+func (bb *branchBound) expired() bool {
+	if bb.stopped || bb.deadline.IsZero() {
+		return bb.stopped
+	}
+
+	bb.nodes++
+	if bb.nodes%1024 == 0 && time.Now().After(bb.deadline) {
+		bb.stopped = true
+	}
+	return bb.stopped
+}
+
+// bound returns an upper bound on the value that could be reached from
+// position `k` in `order`, by greedily filling the `remaining` capacity and
+// then packing whatever fraction of the next item still fits.
+func (bb *branchBound) bound(k int, remaining int64, value int64) int64 {
+	for ; k < len(bb.order); k++ {
+		p := bb.items[bb.order[k]]
+		if p.Weight() > remaining {
+			// The fraction `remaining / w` of the item's value, rounded down, is
+			// computed with 128-bit intermediates so that it can't overflow.
+			hi, lo := bits.Mul64(uint64(remaining), uint64(p.Value()))
+			fraction, _ := bits.Div64(hi, lo, uint64(p.Weight()))
+			return value + int64(fraction)
+		}
+
+		remaining -= p.Weight()
+		value += p.Value()
+	}
+
+	return value
+}
+
+// indices returns the indices of the items in the best solution found, in
+// ascending order.
+func (bb *branchBound) indices() []int64 {
+	indices := []int64{}
+	for k, packed := range bb.best {
+		if packed {
+			indices = append(indices, bb.order[k])
+		}
+	}
+
+	sort.Slice(indices, func(a, b int) bool {
+		return indices[a] < indices[b]
+	})
+	return indices
+}
@@ -0,0 +1,135 @@
+package knapsack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKnapsackBranchBound(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			12, 40,
+		},
+		TestKnapsackItem{
+			7, 27,
+		},
+		TestKnapsackItem{
+			11, 35,
+		},
+		TestKnapsackItem{
+			8, 29,
+		},
+		TestKnapsackItem{
+			9, 31,
+		},
+		TestKnapsackItem{
+			0, 2,
+		},
+	}
+
+	for _, capacity := range []int64{0, 1, 5, 10, 20, 26, 40, 100} {
+		_, expected := KnapsackWithValue(items, capacity)
+
+		var value int64 = 0
+		for _, i := range KnapsackBranchBound(items, capacity) {
+			value += items[i].Value()
+		}
+
+		if value != expected {
+			t.Errorf("Expected %d, got %d", expected, value)
+		}
+	}
+}
+
+func TestKnapsackBranchBoundHugeCapacity(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			4000000000, 5,
+		},
+		TestKnapsackItem{
+			3000000000, 3,
+		},
+		TestKnapsackItem{
+			2000000000, 4,
+		},
+	}
+
+	var value int64 = 0
+	for _, i := range KnapsackBranchBound(items, 6000000000) {
+		value += items[i].Value()
+	}
+
+	if value != 9 {
+		t.Errorf("Expected %d, got %d", 9, value)
+	}
+}
+
+func TestKnapsackDeadline(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			12, 40,
+		},
+		TestKnapsackItem{
+			7, 27,
+		},
+		TestKnapsackItem{
+			11, 35,
+		},
+		TestKnapsackItem{
+			8, 29,
+		},
+		TestKnapsackItem{
+			9, 31,
+		},
+	}
+
+	_, expected := KnapsackWithValue(items, 26)
+	indices, optimal := KnapsackDeadline(items, 26, time.Minute)
+	if !optimal {
+		t.Errorf("Expected the search to finish")
+	}
+
+	var value int64 = 0
+	for _, i := range indices {
+		value += items[i].Value()
+	}
+	if value != expected {
+		t.Errorf("Expected %d, got %d", expected, value)
+	}
+}
+
+func TestKnapsackDeadlineExpired(t *testing.T) {
+	// Items of nearly equal density give the bound very little to prune.
+	items := make([]Packable, 200)
+	for i := range items {
+		items[i] = TestKnapsackItem{
+			int64(1000 + i), int64(1000 + i + i%3),
+		}
+	}
+
+	indices, optimal := KnapsackDeadline(items, 50000, time.Millisecond)
+	if optimal {
+		t.Skip("search finished before the deadline")
+	}
+
+	var weight int64 = 0
+	for _, i := range indices {
+		weight += items[i].Weight()
+	}
+	if len(indices) == 0 || weight > 50000 {
+		t.Errorf("Expected a feasible incumbent, got %v", indices)
+	}
+}
+
+func TestKnapsackDeadlineAtLeastGreedy(t *testing.T) {
+	items := RandomItems(5000, 100, 100, 1)
+
+	// Without any time to search, the greedy packing is all there is.
+	indices, _ := KnapsackDeadline(items, 50000, 0)
+	weight, _ := WeightOf(items, indices)
+	value, _ := ValueOf(items, indices)
+	greedy, _ := ValueOf(items, KnapsackGreedy(items, 50000))
+	if weight > 50000 || value < greedy {
+		t.Errorf("Expected a value of at least %d within %d, got %d weighing %d", greedy, 50000, value, weight)
+	}
+}
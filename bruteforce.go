@@ -0,0 +1,44 @@
+package knapsack
+
+// BruteForce solves the 0/1 Knapsack problem by trying every one of the 2^N
+// subsets of the items, returning the indices of the most valuable subset
+// that fits within the capacity, in ascending order. If several subsets are
+// worth the same, the first one found is returned, so the items may differ
+// from those that Knapsack packs, but their value never does.
+//
+// This is only practical for a couple of dozen items, but as it obviously
+// finds the optimum it's useful for checking the other solvers against. As a
+// subset is stored as a 64 bit mask, BruteForce panics if there are more than
+// 63 items.
+func BruteForce(items []Packable, capacity int64) []int64 {
+	if len(items) > 63 {
+		panic("knapsack: BruteForce can solve for at most 63 items")
+	}
+
+	var best uint64
+	var bestValue int64
+	found := false
+
+	for mask := uint64(0); mask < 1<<uint(len(items)); mask++ {
+		var weight, value int64
+		for i, item := range items {
+			if mask&(1<<uint(i)) != 0 {
+				weight += item.Weight()
+				value += item.Value()
+			}
+		}
+
+		if weight <= capacity && (!found || value > bestValue) {
+			best, bestValue, found = mask, value, true
+		}
+	}
+
+	indices := []int64{}
+	for i := range items {
+		if best&(1<<uint(i)) != 0 {
+			indices = append(indices, int64(i))
+		}
+	}
+
+	return indices
+}
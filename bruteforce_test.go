@@ -0,0 +1,126 @@
+package knapsack
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBruteForce(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	if indices := BruteForce(items, 5); !reflect.DeepEqual(indices, []int64{0, 2}) {
+		t.Errorf("Expected %v, got %v", []int64{0, 2}, indices)
+	}
+	if indices := BruteForce(nil, 5); indices == nil || len(indices) != 0 {
+		t.Errorf("Expected an empty slice, got %#v", indices)
+	}
+}
+
+// randomProblem generates a small Knapsack problem, including items that
+// weigh nothing, items that aren't worth anything and negative capacities,
+// for checking solvers against BruteForce.
+func randomProblem(r *rand.Rand) ([]Packable, int64) {
+	items := make([]Packable, r.Intn(13))
+	for i := range items {
+		items[i] = TestKnapsackItem{
+			r.Int63n(11), r.Int63n(24) - 3,
+		}
+	}
+
+	return items, r.Int63n(34) - 3
+}
+
+func TestKnapsackMatchesBruteForce(t *testing.T) {
+	solvers := map[string]func([]Packable, int64) []int64{
+		"Knapsack":            Knapsack,
+		"KnapsackMinWeight":   KnapsackMinWeight,
+		"KnapsackFillBias":    KnapsackFillBias,
+		"KnapsackCompact":     KnapsackCompact,
+		"KnapsackNoKeep":      KnapsackNoKeep,
+		"KnapsackSparse":      KnapsackSparse,
+		"KnapsackAuto":        KnapsackAuto,
+		"KnapsackMemo":        KnapsackMemo,
+		"KnapsackMITM":        KnapsackMITM,
+		"KnapsackBranchBound": KnapsackBranchBound,
+		"KnapsackFewestItems": KnapsackFewestItems,
+		"KnapsackParallel": func(items []Packable, capacity int64) []int64 {
+			return KnapsackParallel(items, capacity, 3)
+		},
+		"KnapsackExclusive": func(items []Packable, capacity int64) []int64 {
+			indices, _ := KnapsackExclusive(items, nil, capacity)
+			return indices
+		},
+		"KnapsackMaxCount": func(items []Packable, capacity int64) []int64 {
+			return KnapsackMaxCount(items, capacity, len(items))
+		},
+		"Solver": func(items []Packable, capacity int64) []int64 {
+			return NewSolver(items, capacity).Indices(capacity)
+		},
+		"Solver.AddItem": func(items []Packable, capacity int64) []int64 {
+			s := NewSolver(nil, capacity)
+			indices := []int64{}
+			for _, item := range items {
+				indices = s.AddItem(item)
+			}
+			return indices
+		},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 500; trial++ {
+		items, capacity := randomProblem(r)
+		optimal, _ := ValueOf(items, BruteForce(items, capacity))
+
+		if value := MaxValue(items, capacity); value != optimal {
+			t.Errorf("Trial %d: MaxValue: expected %d, got %d for %v within %d", trial, optimal, value, items, capacity)
+		}
+
+		// At least the set BruteForce found is optimal, unless the capacity is
+		// negative and nothing fits.
+		if count := CountOptimal(items, capacity); (capacity < 0) != (count == 0) {
+			t.Errorf("Trial %d: CountOptimal: got %d for %v within %d", trial, count, items, capacity)
+		}
+
+		// The last cells of the table and the sweep hold the optimal value,
+		// unless the capacity is negative and there are no cells at all.
+		table, sweep := KnapsackTable(items, capacity), KnapsackSweep(items, capacity)
+		if capacity < 0 {
+			if len(table) != 0 || len(sweep) != 0 {
+				t.Errorf("Trial %d: expected no cells within %d, got %v and %v", trial, capacity, table, sweep)
+			}
+		} else if table[len(items)][capacity] != optimal || sweep[capacity] != optimal {
+			t.Errorf("Trial %d: expected a value of %d in the table and sweep for %v within %d", trial, optimal, items, capacity)
+		}
+
+		if counts, err := UnboundedKnapsack(items, capacity); capacity < 0 && err == nil && len(counts) != 0 {
+			t.Errorf("Trial %d: UnboundedKnapsack: expected nothing within %d, got %v", trial, capacity, counts)
+		}
+
+		for name, solve := range solvers {
+			indices := solve(items, capacity)
+
+			value, err := ValueOf(items, indices)
+			if err != nil {
+				t.Errorf("Trial %d: %s: %v: %v", trial, name, err, indices)
+				continue
+			}
+			weight, _ := WeightOf(items, indices)
+
+			// Nothing at all is packed into a negative capacity.
+			if value != optimal || weight > capacity && len(indices) > 0 {
+				t.Errorf("Trial %d: %s: expected a value of %d within %d, got %d weighing %d for %v", trial, name, optimal, capacity, value, weight, items)
+			}
+		}
+	}
+}
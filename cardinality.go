@@ -0,0 +1,107 @@
+package knapsack
+
+import (
+	"math"
+)
+
+// KnapsackExactCount behaves like Knapsack, except that exactly `k` items must
+// be packed, such as when picking a team of a fixed size within a budget. It
+// returns the indices of the items to pack.
+//
+// The DP table gains a dimension for the number of items packed, so this
+// takes O(N x K x M) time, and the table of kept items needs as much memory.
+// ErrInfeasible is returned if no `k` items fit within capacity together.
+func KnapsackExactCount(items []Packable, capacity int64, k int) ([]int64, error) {
+	if k < 0 || k > len(items) {
+		return nil, ErrInfeasible
+	}
+
+	indices, ok := knapsackCount(items, capacity, k, true)
+	if !ok {
+		return nil, ErrInfeasible
+	}
+	return indices, nil
+}
+
+// KnapsackMaxCount behaves like Knapsack, except that at most `k` items may be
+// packed. It returns the indices of the items to pack. When `k` is at least
+// the number of items the limit can never bind, and the result is identical
+// to Knapsack's.
+//
+// Like KnapsackExactCount, this takes O(N x K x M) time and memory.
+func KnapsackMaxCount(items []Packable, capacity int64, k int) []int64 {
+	if k <= 0 {
+		return []int64{}
+	}
+	if k > len(items) {
+		k = len(items)
+	}
+
+	indices, _ := knapsackCount(items, capacity, k, false)
+	return indices
+}
+
+// knapsackCount solves for the best set of at most `k` items, or exactly `k`
+// items if `exact` is true. It returns false if there is no such set within
+// capacity.
+func knapsackCount(items []Packable, capacity int64, k int, exact bool) ([]int64, bool) {
+	// Not even the empty set fits within a negative capacity.
+	if capacity < 0 {
+		return []int64{}, false
+	}
+
+	// `values[j][c]` stores the best value of `j` of the items seen so far (or
+	// up to `j`, if not `exact`) within a capacity of `c`, or math.MinInt64 if
+	// there's no such set. As with KnapsackValueOnly, a single table is reused
+	// for every item, so `keep[i][j][c]` records whether item `i` was packed to
+	// reach each cell.
+	values := make([][]int64, k+1)
+	for j := range values {
+		values[j] = make([]int64, capacity+1)
+		if exact && j > 0 {
+			for c := range values[j] {
+				values[j][c] = math.MinInt64
+			}
+		}
+	}
+
+	keep := make([][][]bool, len(items)+1)
+	for i := 1; i <= len(items); i++ {
+		keep[i] = make([][]bool, k+1)
+		for j := range keep[i] {
+			keep[i][j] = make([]bool, capacity+1)
+		}
+
+		w, v := items[i-1].Weight(), items[i-1].Value()
+
+		// Iterate over counts and capacities in reverse so that each item is
+		// only packed once.
+		for j := k; j >= 1; j-- {
+			for c := capacity; c >= w; c-- {
+				if values[j-1][c-w] == math.MinInt64 {
+					continue
+				}
+				if taken := v + values[j-1][c-w]; taken > values[j][c] {
+					values[j][c] = taken
+					keep[i][j][c] = true
+				}
+			}
+		}
+	}
+
+	if values[k][capacity] == math.MinInt64 {
+		return nil, false
+	}
+
+	j, c := k, capacity
+	indices := []int64{}
+	for n := len(items); n > 0; n-- {
+		if keep[n][j][c] {
+			indices = append(indices, int64(n-1))
+			j--
+			c -= items[n-1].Weight()
+		}
+	}
+
+	return indices, true
+}
@@ -0,0 +1,98 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackExactCount(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+	}
+
+	for k, expected := range []int64{0, 5, 9, 12, 13} {
+		indices, err := KnapsackExactCount(items, 7, k)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(indices) != k {
+			t.Errorf("Expected %d items, got %v", k, indices)
+		}
+
+		var value int64 = 0
+		for _, i := range indices {
+			value += items[i].Value()
+		}
+		if value != expected {
+			t.Errorf("Expected %d, got %d", expected, value)
+		}
+	}
+}
+
+func TestKnapsackExactCountInfeasible(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			4, 4,
+		},
+	}
+
+	if _, err := KnapsackExactCount(items, 4, 2); err != ErrInfeasible {
+		t.Errorf("Expected %v, got %v", ErrInfeasible, err)
+	}
+	if _, err := KnapsackExactCount(items, 10, 4); err != ErrInfeasible {
+		t.Errorf("Expected %v, got %v", ErrInfeasible, err)
+	}
+}
+
+func TestKnapsackMaxCount(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+	}
+
+	for _, k := range []int{4, 5} {
+		indices := KnapsackMaxCount(items, 5, k)
+		expected := Knapsack(items, 5)
+		if len(indices) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, indices)
+		}
+		for i := range expected {
+			if indices[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, indices)
+			}
+		}
+	}
+
+	// The best packing uses three items, worth 10. Limited to one, the most we
+	// can do is the first item.
+	indices := KnapsackMaxCount(items, 5, 1)
+	if len(indices) != 1 || indices[0] != 0 {
+		t.Errorf("Expected %v, got %v", []int64{0}, indices)
+	}
+}
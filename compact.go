@@ -0,0 +1,52 @@
+package knapsack
+
+// KnapsackCompact behaves exactly like Knapsack, returning the same indices,
+// but uses far less memory to do so, which matters when there are very many
+// items or a very large capacity.
+//
+// Knapsack stores a whole int for every cell of its `keep` matrix, and a row
+// of values for every item. KnapsackCompact packs the `keep` matrix into a
+// bitset of one bit per cell, and keeps only a single row of values, which it
+// overwrites as it goes like KnapsackValueOnly. This cuts the memory needed
+// from around 16 bytes per cell to just over 1 bit.
+func KnapsackCompact(items []Packable, capacity int64) []int64 {
+	if len(items) == 0 || capacity < 0 {
+		return []int64{}
+	}
+
+	weights, itemValues := snapshot(items)
+	width := capacity + 1
+
+	// Bit `i*width + c` of `keep` is set if item `i` is packed in the best
+	// combination of the first `i+1` items within a capacity of `c`.
+	keep := make([]uint64, (int64(len(items))*width+63)/64)
+	values := make([]int64, width)
+
+	for i := range items {
+		w, v := weights[i], itemValues[i]
+
+		// As in KnapsackValueOnly, iterate over the capacities in reverse so
+		// that `values[c-w]` still holds the value from the previous row.
+		for c := capacity; c >= w; c-- {
+			if v+values[c-w] > values[c] {
+				values[c] = v + values[c-w]
+
+				bit := int64(i)*width + c
+				keep[bit/64] |= 1 << uint(bit%64)
+			}
+		}
+	}
+
+	// Trace back through the bitset just as traceback does through `keep`.
+	indices := []int64{}
+	c := capacity
+	for i := int64(len(items)) - 1; i >= 0; i-- {
+		bit := i*width + c
+		if keep[bit/64]&(1<<uint(bit%64)) != 0 {
+			indices = append(indices, i)
+			c -= weights[i]
+		}
+	}
+
+	return indices
+}
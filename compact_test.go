@@ -0,0 +1,45 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackCompact(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			0, 2,
+		},
+	}
+
+	for capacity := int64(0); capacity <= 7; capacity++ {
+		expected := Knapsack(items, capacity)
+		if indices := KnapsackCompact(items, capacity); !reflect.DeepEqual(indices, expected) {
+			t.Errorf("Capacity %d: expected %v, got %v", capacity, expected, indices)
+		}
+	}
+
+	if indices := KnapsackCompact(nil, 5); indices == nil || len(indices) != 0 {
+		t.Errorf("Expected an empty slice, got %#v", indices)
+	}
+}
+
+func TestKnapsackCompactRandom(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		items := RandomItems(30, 20, 50, seed)
+
+		expected := Knapsack(items, 100)
+		if indices := KnapsackCompact(items, 100); !reflect.DeepEqual(indices, expected) {
+			t.Errorf("Seed %d: expected %v, got %v", seed, expected, indices)
+		}
+	}
+}
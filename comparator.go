@@ -0,0 +1,51 @@
+package knapsack
+
+// KnapsackComparator behaves like Knapsack, but lets the caller decide how
+// ties are broken. While filling the DP table, whenever packing an item at
+// some capacity would give exactly the same value as leaving it out, the two
+// combinations of items are built as Solutions and passed to `less`: `a` is
+// the combination with the item packed, and `b` the one without it. The item
+// is packed if `less(a, b)` returns true, and left out otherwise, so a `less`
+// that always returns false behaves exactly like Knapsack.
+//
+// The Solutions passed to `less` are partial: they only consider the items up
+// to and including the one being decided, and the capacity at that point in
+// the table, which their RemainingCapacity is measured against. Each call
+// costs O(N) time to build the Solutions, on top of the cost of `less`.
+//
+// Ties are broken one item and capacity at a time, so `less` is only
+// guaranteed to find its preferred solution overall if its preference is
+// unaffected by adding the same item to both combinations, as is the case for
+// preferring the lightest, heaviest or smallest set of items.
+func KnapsackComparator(items []Packable, capacity int64, less func(a, b Solution) bool) []int64 {
+	cfg := defaultConfig()
+	cfg.tieBreak = tieCustom
+	cfg.less = less
+
+	indices, _, _ := knapsack(cfg, items, capacity)
+	return indices
+}
+
+// candidate builds the Solution for item `i` (counting from 1, as fill does)
+// at capacity `c`, either with the item packed, if `take` is true, or left out,
+// from the rows of `keep` that have been filled so far.
+func candidate(itemWeights []int64, itemValues []int64, keep [][]int, i int, c int64, take bool) Solution {
+	var indices []int64
+	remaining := c
+	if take {
+		indices = append(indices, int64(i-1))
+		remaining -= itemWeights[i-1]
+	}
+	indices = append(indices, traceback(itemWeights[:i-1], keep, remaining)...)
+
+	s := Solution{
+		Indices: indices,
+	}
+	for _, index := range indices {
+		s.TotalValue += itemValues[index]
+		s.TotalWeight += itemWeights[index]
+	}
+	s.RemainingCapacity = c - s.TotalWeight
+
+	return s
+}
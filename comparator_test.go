@@ -0,0 +1,84 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackComparator(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 6,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+	}
+
+	lighter := func(a, b Solution) bool {
+		return a.TotalWeight < b.TotalWeight
+	}
+	heavier := func(a, b Solution) bool {
+		return a.TotalWeight > b.TotalWeight
+	}
+	never := func(a, b Solution) bool {
+		return false
+	}
+
+	for capacity := int64(0); capacity <= 8; capacity++ {
+		for _, tc := range []struct {
+			name     string
+			less     func(a, b Solution) bool
+			expected []int64
+		}{
+			{"lighter", lighter, KnapsackMinWeight(items, capacity)},
+			{"heavier", heavier, KnapsackFillBias(items, capacity)},
+			{"never", never, Knapsack(items, capacity)},
+		} {
+			if indices := KnapsackComparator(items, capacity, tc.less); !reflect.DeepEqual(indices, tc.expected) {
+				t.Errorf("%s, capacity %d: expected %v, got %v", tc.name, capacity, tc.expected, indices)
+			}
+		}
+	}
+}
+
+func TestKnapsackComparatorCandidates(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 6,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+	}
+
+	// Ties only come up when deciding on the last item: at a capacity of 2
+	// it's worth the same as the second item, and at 4 it's worth the same as
+	// the first along with the second.
+	var calls int
+	indices := KnapsackComparator(items, 4, func(a, b Solution) bool {
+		calls++
+		if a.TotalValue != b.TotalValue {
+			t.Errorf("Expected candidates of equal value, got %v and %v", a, b)
+		}
+		if a.Indices[0] != 2 {
+			t.Errorf("Expected the item being decided to be packed, got %v", a)
+		}
+		return len(a.Indices) > len(b.Indices)
+	})
+	if calls == 0 {
+		t.Errorf("Expected less to be called")
+	}
+	if !reflect.DeepEqual(indices, []int64{2, 1}) {
+		t.Errorf("Expected %v, got %v", []int64{2, 1}, indices)
+	}
+}
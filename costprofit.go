@@ -0,0 +1,64 @@
+package knapsack
+
+// A CostProfitPackable item is one that both earns a profit, which is to be
+// maximised, and incurs a cost, which is to be minimised, when it's packed.
+type CostProfitPackable interface {
+	Weight() int64
+	Cost() int64
+	Profit() int64
+}
+
+// KnapsackCostProfit packs items into a Knapsack of the given capacity so as
+// to maximise their total profit less their total cost, returning the indices
+// of the packed items in ascending order. Each item is worth an effective
+// value of `Profit() - lambda*Cost()`, and items whose effective value isn't
+// positive are never packed.
+//
+// `lambda` sets how much a unit of cost matters compared to a unit of profit:
+// with a `lambda` of 0 cost is ignored and only profit counts, with 1 they are
+// weighed equally, and as `lambda` grows, cheaper items are increasingly
+// favoured over more profitable ones. `lambda` shouldn't be negative, as that
+// would reward cost.
+func KnapsackCostProfit(items []CostProfitPackable, capacity int64, lambda float64) []int64 {
+	if capacity < 0 {
+		return []int64{}
+	}
+
+	// The effective values aren't integers, so this is Knapsack's DP with a
+	// single row of float64 values, overwritten in reverse as in
+	// KnapsackValueOnly, plus the `keep` matrix for the traceback.
+	values := make([]float64, capacity+1)
+	keep := make([][]bool, len(items))
+
+	for i, item := range items {
+		keep[i] = make([]bool, capacity+1)
+
+		w, v := item.Weight(), float64(item.Profit())-lambda*float64(item.Cost())
+		if v <= 0 {
+			continue
+		}
+
+		for c := capacity; c >= w; c-- {
+			if v+values[c-w] > values[c] {
+				values[c] = v + values[c-w]
+				keep[i][c] = true
+			}
+		}
+	}
+
+	var packed []int64
+	c := capacity
+	for i := len(items) - 1; i >= 0; i-- {
+		if keep[i][c] {
+			packed = append(packed, int64(i))
+			c -= items[i].Weight()
+		}
+	}
+
+	indices := make([]int64, 0, len(packed))
+	for i := len(packed) - 1; i >= 0; i-- {
+		indices = append(indices, packed[i])
+	}
+
+	return indices
+}
@@ -0,0 +1,66 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCostProfitItem is a CostProfitPackable with fixed attributes.
+type TestCostProfitItem struct {
+	weight, cost, profit int64
+}
+
+func (i TestCostProfitItem) Weight() int64 {
+	return i.weight
+}
+
+func (i TestCostProfitItem) Cost() int64 {
+	return i.cost
+}
+
+func (i TestCostProfitItem) Profit() int64 {
+	return i.profit
+}
+
+func TestKnapsackCostProfit(t *testing.T) {
+	items := []CostProfitPackable{
+		TestCostProfitItem{
+			2, 8, 10,
+		},
+		TestCostProfitItem{
+			2, 1, 6,
+		},
+		TestCostProfitItem{
+			1, 5, 4,
+		},
+	}
+
+	for _, tc := range []struct {
+		lambda   float64
+		expected []int64
+	}{
+		// Ignoring cost, the two most profitable items fit.
+		{0, []int64{0, 1}},
+		// With cost weighed equally, the last item is worth -1, and the first
+		// is only worth 2 to the second's 5.
+		{1, []int64{0, 1}},
+		// When cost matters more, the first item is worth less than nothing.
+		{2, []int64{1}},
+	} {
+		if indices := KnapsackCostProfit(items, 4, tc.lambda); !reflect.DeepEqual(indices, tc.expected) {
+			t.Errorf("Lambda %v: expected %v, got %v", tc.lambda, tc.expected, indices)
+		}
+	}
+}
+
+func TestKnapsackCostProfitNothingWorthPacking(t *testing.T) {
+	items := []CostProfitPackable{
+		TestCostProfitItem{
+			1, 5, 5,
+		},
+	}
+
+	if indices := KnapsackCostProfit(items, 4, 1); len(indices) != 0 {
+		t.Errorf("Expected no items, got %v", indices)
+	}
+}
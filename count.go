@@ -0,0 +1,123 @@
+package knapsack
+
+// CountOptimal returns the number of distinct sets of items that achieve the
+// maximum value packable within capacity. Two sets are distinct if they
+// differ by at least one index, so identical items at different indices count
+// separately, as do sets that differ only by items with no value.
+//
+// Alongside the usual table of values, a table of counts stores the number
+// of sets of the first `i` items that reach `values[i][c]` within a capacity
+// of `c`. Sets that pack item `i` and sets that don't are always distinct, so
+// when both branches tie their counts are simply added together. The count
+// can grow exponentially with the number of items, and will overflow an
+// int64 if more than 2^63 - 1 optimal sets exist. No set of items fits within
+// a negative capacity, not even the empty set, so none are counted.
+func CountOptimal(items []Packable, capacity int64) int64 {
+	if capacity < 0 {
+		return 0
+	}
+
+	values := make([][]int64, len(items)+1)
+	counts := make([][]int64, len(items)+1)
+	for i := range values {
+		values[i] = make([]int64, capacity+1)
+		counts[i] = make([]int64, capacity+1)
+	}
+
+	// With no items, the empty set is the only (and so the best) set at every
+	// capacity.
+	for c := range counts[0] {
+		counts[0][c] = 1
+	}
+
+	for i := 1; i <= len(items); i++ {
+		w, v := items[i-1].Weight(), items[i-1].Value()
+
+		for c := int64(0); c <= capacity; c++ {
+			values[i][c] = values[i-1][c]
+			counts[i][c] = counts[i-1][c]
+
+			if w > c {
+				continue
+			}
+
+			switch taken := v + values[i-1][c-w]; {
+			case taken > values[i][c]:
+				values[i][c] = taken
+				counts[i][c] = counts[i-1][c-w]
+			case taken == values[i][c]:
+				counts[i][c] += counts[i-1][c-w]
+			}
+		}
+	}
+
+	return counts[len(items)][capacity]
+}
+
+// AllOptimal returns every distinct set of items that achieves the maximum
+// value packable within capacity, with each set's indices in ascending order.
+// The number of optimal sets can grow exponentially with the number of items
+// (see CountOptimal), so at most `limit` sets are returned; a limit of zero
+// or less returns them all.
+//
+// After filling the usual table of values, the sets are found by walking back
+// from the final cell just like Knapsack's traceback, except that wherever
+// leaving an item out and packing it both lead to the same value, both
+// branches are followed.
+func AllOptimal(items []Packable, capacity int64, limit int) [][]int64 {
+	if capacity < 0 {
+		return [][]int64{}
+	}
+
+	values := make([][]int64, len(items)+1)
+	for i := range values {
+		values[i] = make([]int64, capacity+1)
+	}
+
+	for i := 1; i <= len(items); i++ {
+		w, v := items[i-1].Weight(), items[i-1].Value()
+
+		for c := int64(0); c <= capacity; c++ {
+			values[i][c] = values[i-1][c]
+			if w <= c && v+values[i-1][c-w] > values[i][c] {
+				values[i][c] = v + values[i-1][c-w]
+			}
+		}
+	}
+
+	solutions := [][]int64{}
+	var chosen []int64
+
+	// walk finds the sets of the first `i` items that reach `values[i][c]`,
+	// where `chosen` holds the items already packed from later rows. It
+	// returns false once the limit has been reached.
+	var walk func(i int, c int64) bool
+	walk = func(i int, c int64) bool {
+		if i == 0 {
+			// `chosen` was built from the last item backwards.
+			solution := make([]int64, len(chosen))
+			for k, index := range chosen {
+				solution[len(chosen)-1-k] = index
+			}
+			solutions = append(solutions, solution)
+			return limit <= 0 || len(solutions) < limit
+		}
+
+		if values[i-1][c] == values[i][c] && !walk(i-1, c) {
+			return false
+		}
+
+		w, v := items[i-1].Weight(), items[i-1].Value()
+		if w <= c && v+values[i-1][c-w] == values[i][c] {
+			chosen = append(chosen, int64(i-1))
+			more := walk(i-1, c-w)
+			chosen = chosen[:len(chosen)-1]
+			return more
+		}
+
+		return true
+	}
+	walk(len(items), capacity)
+
+	return solutions
+}
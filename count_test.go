@@ -0,0 +1,82 @@
+package knapsack
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCountOptimal(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			4, 6,
+		},
+		TestKnapsackItem{
+			1, 0,
+		},
+	}
+
+	// {0, 1} and {2} are both worth 6 within a capacity of 4.
+	if count := CountOptimal(items, 4); count != 2 {
+		t.Errorf("Expected %d, got %d", 2, count)
+	}
+
+	// With a capacity of 5, each of those sets can also include the worthless
+	// last item.
+	if count := CountOptimal(items, 5); count != 4 {
+		t.Errorf("Expected %d, got %d", 4, count)
+	}
+
+	if count := CountOptimal([]Packable{}, 5); count != 1 {
+		t.Errorf("Expected %d, got %d", 1, count)
+	}
+}
+
+func TestAllOptimal(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			4, 6,
+		},
+		TestKnapsackItem{
+			1, 0,
+		},
+	}
+
+	solutions := AllOptimal(items, 5, 0)
+	if int64(len(solutions)) != CountOptimal(items, 5) {
+		t.Fatalf("Expected %d solutions, got %v", CountOptimal(items, 5), solutions)
+	}
+
+	seen := make(map[string]bool)
+	for _, solution := range solutions {
+		var value, weight int64 = 0, 0
+		for _, i := range solution {
+			value += items[i].Value()
+			weight += items[i].Weight()
+		}
+		if value != 6 || weight > 5 {
+			t.Errorf("Expected a value of 6 within a weight of 5, got %v", solution)
+		}
+
+		key := fmt.Sprint(solution)
+		if seen[key] {
+			t.Errorf("Solution %v was returned more than once", solution)
+		}
+		seen[key] = true
+	}
+
+	if solutions := AllOptimal(items, 5, 3); len(solutions) != 3 {
+		t.Errorf("Expected 3 solutions, got %v", solutions)
+	}
+}
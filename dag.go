@@ -0,0 +1,167 @@
+package knapsack
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// KnapsackDAG packs items into a Knapsack such that, whenever item `i` is
+// packed, every item listed in `deps[i]` is packed too, along with their own
+// dependencies and so on, so that the packed items are always closed under
+// the dependencies. It returns the indices of the items to pack in ascending
+// order.
+//
+// Unlike KnapsackWithDeps, which is a greedy heuristic, this is exact for any
+// directed acyclic graph of dependencies. It's a branch-and-bound search over
+// the items in topological order, so that every dependency of an item has
+// already been decided by the time the item is, and an item can only be
+// packed if its direct dependencies have been. A branch is abandoned once the
+// fractional relaxation of the remaining items, ignoring their dependencies,
+// can't beat the best solution found so far, as in KnapsackBranchBound. The
+// worst case is exponential in the number of items, so this suits problems
+// with tens of items rather than thousands.
+//
+// ErrIndexOutOfRange is returned if `deps` refers to an index that isn't one
+// of the items, and ErrCyclicDependency if the dependencies contain a cycle.
+func KnapsackDAG(items []Packable, deps map[int64][]int64, capacity int64) ([]int64, error) {
+	if err := checkDependencies(len(items), deps); err != nil {
+		return nil, err
+	}
+	if capacity < 0 {
+		return []int64{}, nil
+	}
+
+	d := newDAGSearch(items, deps)
+	d.search(0, capacity, 0)
+
+	indices := append([]int64{}, d.best...)
+	sort.Slice(indices, func(a, b int) bool {
+		return indices[a] < indices[b]
+	})
+	return indices, nil
+}
+
+// dagSearch holds the state of the search made by KnapsackDAG.
+type dagSearch struct {
+	items []Packable
+	deps  map[int64][]int64
+
+	// order holds the indices of the items in topological order, with every
+	// item after its dependencies, and byDensity holds the indices of the
+	// items with a positive value in order of descending value density.
+	order     []int64
+	byDensity []int64
+
+	// packed and decided record, for each item, whether it's packed on the
+	// current branch and whether it's been decided at all. current lists the
+	// items packed on the current branch, and best those in the best solution
+	// found so far.
+	packed    []bool
+	decided   []bool
+	current   []int64
+	best      []int64
+	bestValue int64
+}
+
+func newDAGSearch(items []Packable, deps map[int64][]int64) *dagSearch {
+	d := &dagSearch{
+		items:   items,
+		deps:    deps,
+		packed:  make([]bool, len(items)),
+		decided: make([]bool, len(items)),
+	}
+
+	// A depth-first search that adds each item once its dependencies have
+	// been added gives a topological order. checkDependencies has already
+	// ruled out cycles.
+	added := make([]bool, len(items))
+	var add func(i int64)
+	add = func(i int64) {
+		added[i] = true
+		for _, dep := range deps[i] {
+			if !added[dep] {
+				add(dep)
+			}
+		}
+		d.order = append(d.order, i)
+	}
+	for i := range items {
+		if !added[i] {
+			add(int64(i))
+		}
+	}
+
+	for _, i := range SortByDensity(items) {
+		if items[i].Value() > 0 && items[i].Weight() >= 0 {
+			d.byDensity = append(d.byDensity, i)
+		}
+	}
+
+	return d
+}
+
+// search explores every way of packing the items from position `k` in
+// `order` onwards, given the `remaining` capacity and the `value` already
+// packed on this branch.
+func (d *dagSearch) search(k int, remaining int64, value int64) {
+	if value > d.bestValue {
+		d.bestValue = value
+		d.best = append(d.best[:0], d.current...)
+	}
+
+	if k == len(d.order) || d.bound(remaining, value) <= d.bestValue {
+		return
+	}
+
+	i := d.order[k]
+	d.decided[i] = true
+
+	if p := d.items[i]; p.Weight() >= 0 && p.Weight() <= remaining && d.ready(i) {
+		d.packed[i] = true
+		d.current = append(d.current, i)
+		d.search(k+1, remaining-p.Weight(), value+p.Value())
+		d.current = d.current[:len(d.current)-1]
+		d.packed[i] = false
+	}
+
+	d.search(k+1, remaining, value)
+	d.decided[i] = false
+}
+
+// ready reports whether every direct dependency of item `i` is packed on the
+// current branch.
+func (d *dagSearch) ready(i int64) bool {
+	for _, dep := range d.deps[i] {
+		if !d.packed[dep] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bound returns an upper bound on the value that could be reached on the
+// current branch, by greedily filling the `remaining` capacity with the
+// undecided items in order of value density, ignoring their dependencies, and
+// then packing whatever fraction of the next item still fits.
+func (d *dagSearch) bound(remaining int64, value int64) int64 {
+	for _, i := range d.byDensity {
+		if d.decided[i] {
+			continue
+		}
+
+		p := d.items[i]
+		if p.Weight() > remaining {
+			// As in branchBound.bound, the fraction of the item's value is
+			// computed with 128-bit intermediates so that it can't overflow.
+			hi, lo := bits.Mul64(uint64(remaining), uint64(p.Value()))
+			fraction, _ := bits.Div64(hi, lo, uint64(p.Weight()))
+			return value + int64(fraction)
+		}
+
+		remaining -= p.Weight()
+		value += p.Value()
+	}
+
+	return value
+}
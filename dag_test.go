@@ -0,0 +1,132 @@
+package knapsack
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackDAG(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 1,
+		},
+		TestKnapsackItem{
+			2, 0,
+		},
+		TestKnapsackItem{
+			2, 10,
+		},
+		TestKnapsackItem{
+			2, 6,
+		},
+	}
+
+	// The most valuable item needs both of the first two, which leaves no
+	// room for the last.
+	deps := map[int64][]int64{
+		2: {1},
+		1: {0},
+	}
+
+	for _, tc := range []struct {
+		capacity int64
+		expected []int64
+	}{
+		{4, []int64{0, 3}},
+		{5, []int64{0, 1, 2}},
+		{7, []int64{0, 1, 2, 3}},
+	} {
+		indices, err := KnapsackDAG(items, deps, tc.capacity)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(indices, tc.expected) {
+			t.Errorf("Capacity %d: expected %v, got %v", tc.capacity, tc.expected, indices)
+		}
+	}
+}
+
+func TestKnapsackDAGRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for trial := 0; trial < 50; trial++ {
+		items := RandomItems(10, 6, 10, int64(trial))
+
+		// Only depending on earlier items keeps the graph acyclic.
+		deps := make(map[int64][]int64)
+		for i := int64(1); i < int64(len(items)); i++ {
+			for j := int64(0); j < i; j++ {
+				if r.Intn(5) == 0 {
+					deps[i] = append(deps[i], j)
+				}
+			}
+		}
+
+		indices, err := KnapsackDAG(items, deps, 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		packed := make(map[int64]bool)
+		var weight, value int64
+		for _, i := range indices {
+			packed[i] = true
+			weight += items[i].Weight()
+			value += items[i].Value()
+		}
+		for _, i := range indices {
+			for _, dep := range deps[i] {
+				if !packed[dep] {
+					t.Errorf("Trial %d: packed item %d without its dependency %d", trial, i, dep)
+				}
+			}
+		}
+		if weight > 15 {
+			t.Errorf("Trial %d: expected a weight of at most %d, got %d", trial, 15, weight)
+		}
+
+		// Check the value against every closed set of items.
+		var best int64
+		for mask := 0; mask < 1<<len(items); mask++ {
+			var w, v int64
+			closed := true
+			for i := range items {
+				if mask&(1<<i) == 0 {
+					continue
+				}
+				w += items[i].Weight()
+				v += items[i].Value()
+				for _, dep := range deps[int64(i)] {
+					if mask&(1<<dep) == 0 {
+						closed = false
+					}
+				}
+			}
+			if closed && w <= 15 && v > best {
+				best = v
+			}
+		}
+		if value != best {
+			t.Errorf("Trial %d: expected %d, got %d", trial, best, value)
+		}
+	}
+}
+
+func TestKnapsackDAGInvalid(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 1,
+		},
+	}
+
+	if _, err := KnapsackDAG(items, map[int64][]int64{0: {1}, 1: {0}}, 5); err != ErrCyclicDependency {
+		t.Errorf("Expected %v, got %v", ErrCyclicDependency, err)
+	}
+	if _, err := KnapsackDAG(items, map[int64][]int64{0: {2}}, 5); err != ErrIndexOutOfRange {
+		t.Errorf("Expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+}
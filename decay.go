@@ -0,0 +1,101 @@
+package knapsack
+
+import (
+	"math"
+	"sort"
+)
+
+// KnapsackDecay packs items whose value decays the later they are packed: the
+// item packed at position `k`, counting from 0, is worth `Value() * decay^k`.
+// It returns the indices of the items to pack within the given capacity, in
+// the order in which they should be packed, so as to maximise their total
+// decayed value. ErrInvalidDecay is returned unless 0 < decay <= 1.
+//
+// Whatever items are packed, the order that loses the least to decay is the
+// one that packs the most valuable first, so the DP considers the items in
+// order of descending value and only has to track how many items have been
+// packed so far. `values[k][c]` holds the best decayed value of exactly `k`
+// items within a capacity of `c`, which makes the solution exact, but takes
+// O(N^2 x M) time and memory rather than Knapsack's O(N x M).
+func KnapsackDecay(items []Packable, capacity int64, decay float64) ([]int64, error) {
+	if !(decay > 0 && decay <= 1) {
+		return nil, ErrInvalidDecay
+	}
+	if capacity < 0 {
+		return []int64{}, nil
+	}
+
+	// Only items that add some value are worth considering; the rest are left
+	// out of `order`, which holds the indices of those that are by descending
+	// value.
+	var order []int64
+	for i, item := range items {
+		if item.Value() > 0 && item.Weight() >= 0 {
+			order = append(order, int64(i))
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return items[order[a]].Value() > items[order[b]].Value()
+	})
+
+	values := make([][]float64, len(order)+1)
+	for k := range values {
+		values[k] = make([]float64, capacity+1)
+		if k > 0 {
+			for c := range values[k] {
+				values[k][c] = math.Inf(-1)
+			}
+		}
+	}
+
+	// `factors[k]` is the factor by which the item in position `k` decays.
+	factors := make([]float64, len(order))
+	for k, factor := 0, 1.0; k < len(factors); k, factor = k+1, factor*decay {
+		factors[k] = factor
+	}
+
+	// `keep[s][k][c]` records whether the `s`th item in `order` is packed, in
+	// position `k-1`, in the best set of `k` items within a capacity of `c`.
+	keep := make([][][]bool, len(order))
+
+	for s, i := range order {
+		w, v := items[i].Weight(), float64(items[i].Value())
+
+		keep[s] = make([][]bool, s+2)
+		for k := s + 1; k >= 1; k-- {
+			keep[s][k] = make([]bool, capacity+1)
+
+			// As in KnapsackValueOnly, iterate over the capacities in reverse
+			// so that `values[k-1][c-w]` still holds the previous item's value.
+			for c := capacity; c >= w; c-- {
+				if math.IsInf(values[k-1][c-w], -1) {
+					continue
+				}
+				if taken := values[k-1][c-w] + v*factors[k-1]; taken > values[k][c] {
+					values[k][c] = taken
+					keep[s][k][c] = true
+				}
+			}
+		}
+	}
+
+	// Of the sets worth the most, take the one with the fewest items.
+	best := 0
+	for k := range values {
+		if values[k][capacity] > values[best][capacity] {
+			best = k
+		}
+	}
+
+	packed := make([]int64, best)
+	k, c := best, capacity
+	for s := len(order) - 1; s >= 0 && k > 0; s-- {
+		if keep[s][k][c] {
+			packed[k-1] = order[s]
+			c -= items[order[s]].Weight()
+			k--
+		}
+	}
+
+	return packed, nil
+}
@@ -0,0 +1,72 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackDecay(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 6,
+		},
+		TestKnapsackItem{
+			2, 10,
+		},
+		TestKnapsackItem{
+			1, 6,
+		},
+	}
+
+	for _, tc := range []struct {
+		decay    float64
+		expected []int64
+	}{
+		// Without decay this is just Knapsack, packing the two light items.
+		{1, []int64{0, 2}},
+		// With a little decay, the light items are still worth 6 + 4.8.
+		{0.8, []int64{0, 2}},
+		// With more, they are only worth 6 + 3, less than the heavy item.
+		{0.5, []int64{1}},
+	} {
+		indices, err := KnapsackDecay(items, 2, tc.decay)
+		if err != nil {
+			t.Errorf("Decay %v: expected no error, got %v", tc.decay, err)
+			continue
+		}
+		if !reflect.DeepEqual(indices, tc.expected) {
+			t.Errorf("Decay %v: expected %v, got %v", tc.decay, tc.expected, indices)
+		}
+	}
+}
+
+func TestKnapsackDecayOrder(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			1, 10,
+		},
+		TestKnapsackItem{
+			1, 5,
+		},
+	}
+
+	// Everything fits, and is packed from the most valuable down.
+	indices, err := KnapsackDecay(items, 3, 0.5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(indices, []int64{1, 2, 0}) {
+		t.Errorf("Expected %v, got %v", []int64{1, 2, 0}, indices)
+	}
+}
+
+func TestKnapsackDecayInvalid(t *testing.T) {
+	for _, decay := range []float64{0, -0.5, 1.5} {
+		if _, err := KnapsackDecay(nil, 4, decay); err != ErrInvalidDecay {
+			t.Errorf("Decay %v: expected %v, got %v", decay, ErrInvalidDecay, err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package knapsack
+
+// Deduplicate collapses identical items, those with the same weight and
+// value, so that they can be packed as a single bounded item by
+// BoundedKnapsack rather than as separate rows of the 0/1 DP table. It
+// returns the distinct items, in the order in which each first appears in
+// `items`, along with the number of copies of each, and a mapping from every
+// index in `items` to the index of its distinct item in `unique`.
+//
+// To expand a solution for the distinct items back into indices of `items`,
+// give each distinct item that's packed `k` times the first `k` indices that
+// map to it, just as Knapsack packs the first copies of identical items.
+func Deduplicate(items []Packable) (unique []Packable, counts []int64, mapping []int64) {
+	unique = []Packable{}
+	counts = []int64{}
+	mapping = make([]int64, len(items))
+
+	seen := make(map[[2]int64]int64)
+	for i, item := range items {
+		key := [2]int64{item.Weight(), item.Value()}
+
+		u, ok := seen[key]
+		if !ok {
+			u = int64(len(unique))
+			seen[key] = u
+			unique = append(unique, item)
+			counts = append(counts, 0)
+		}
+
+		counts[u]++
+		mapping[i] = u
+	}
+
+	return unique, counts, mapping
+}
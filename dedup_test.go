@@ -0,0 +1,72 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeduplicate(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 4,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+	}
+
+	unique, counts, mapping := Deduplicate(items)
+
+	expectedUnique := []Packable{items[0], items[1], items[3]}
+	if !reflect.DeepEqual(unique, expectedUnique) {
+		t.Errorf("Expected %v, got %v", expectedUnique, unique)
+	}
+	if !reflect.DeepEqual(counts, []int64{3, 1, 1}) {
+		t.Errorf("Expected %v, got %v", []int64{3, 1, 1}, counts)
+	}
+	if !reflect.DeepEqual(mapping, []int64{0, 1, 0, 2, 0}) {
+		t.Errorf("Expected %v, got %v", []int64{0, 1, 0, 2, 0}, mapping)
+	}
+}
+
+func TestDeduplicateExpand(t *testing.T) {
+	items := RandomItems(40, 5, 5, 1)
+	unique, counts, mapping := Deduplicate(items)
+	if len(unique) >= len(items) {
+		t.Fatalf("Expected some duplicates among %d items", len(items))
+	}
+
+	packed, err := BoundedKnapsack(unique, counts, 30)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Expand the packed copies of each distinct item into the first indices
+	// that map to it.
+	copies := make([]int64, len(unique))
+	for _, u := range packed {
+		copies[u]++
+	}
+	var value, weight int64
+	for i, u := range mapping {
+		if copies[u] > 0 {
+			copies[u]--
+			value += items[i].Value()
+			weight += items[i].Weight()
+		}
+	}
+
+	_, expected := KnapsackWithValue(items, 30)
+	if value != expected || weight > 30 {
+		t.Errorf("Expected a value of %d within %d, got %d weighing %d", expected, 30, value, weight)
+	}
+}
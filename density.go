@@ -0,0 +1,46 @@
+package knapsack
+
+import (
+	"sort"
+)
+
+// density returns the value per unit of weight of an item with a positive
+// weight.
+func density(p Packable) float64 {
+	return float64(p.Value()) / float64(p.Weight())
+}
+
+// SortByDensity returns the indices of `items` ordered by descending value
+// density, that is value per unit of weight. Items without a weight can't be
+// given a density, so those with a positive value are placed first (they're
+// worth packing whatever the capacity) and the rest are placed last. Items
+// with equal densities keep their original order.
+func SortByDensity(items []Packable) []int64 {
+	// rank groups the items into those that come before, among and after the
+	// items with a density.
+	rank := func(p Packable) int {
+		switch {
+		case p.Weight() != 0:
+			return 1
+		case p.Value() > 0:
+			return 0
+		default:
+			return 2
+		}
+	}
+
+	order := make([]int64, len(items))
+	for i := range order {
+		order[i] = int64(i)
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		pa, pb := items[order[a]], items[order[b]]
+		if ra, rb := rank(pa), rank(pb); ra != rb || ra != 1 {
+			return ra < rb
+		}
+		return density(pa) > density(pb)
+	})
+
+	return order
+}
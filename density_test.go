@@ -0,0 +1,41 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestSortByDensity(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			0, 0,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			0, 2,
+		},
+		TestKnapsackItem{
+			4, 6,
+		},
+		TestKnapsackItem{
+			3, 5,
+		},
+	}
+
+	// The zero weight item with a value comes first, followed by the densities
+	// 4, 5/3, 1.5 (in their original order) and finally the worthless item.
+	order := SortByDensity(items)
+	expected := []int64{3, 2, 5, 1, 4, 0}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, order)
+		}
+	}
+}
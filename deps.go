@@ -0,0 +1,138 @@
+package knapsack
+
+// KnapsackWithDeps packs items into a Knapsack such that, whenever item `i` is
+// packed, every item listed in `deps[i]` is packed too, along with their own
+// dependencies and so on. It returns the indices of the items to pack.
+//
+// Packing under dependencies is much harder than the 0/1 problem, so this is
+// a greedy heuristic rather than an exact solver: at each step it considers
+// packing each remaining item along with all of its unpacked dependencies,
+// and packs whichever of those bundles fits and has the greatest value
+// density, until nothing more fits. This takes O(N^3) time in the worst case.
+//
+// ErrIndexOutOfRange is returned if `deps` refers to an index that isn't one
+// of the items, and ErrCyclicDependency if the dependencies contain a cycle.
+func KnapsackWithDeps(items []Packable, deps map[int64][]int64, capacity int64) ([]int64, error) {
+	if err := checkDependencies(len(items), deps); err != nil {
+		return nil, err
+	}
+
+	packed := make([]bool, len(items))
+	remaining := capacity
+	indices := []int64{}
+
+	for {
+		var best []int64
+		var bestWeight, bestValue int64
+
+		for i := range items {
+			if packed[i] {
+				continue
+			}
+
+			bundle := bundle(int64(i), deps, packed)
+			var weight, value int64
+			for _, j := range bundle {
+				weight += items[j].Weight()
+				value += items[j].Value()
+			}
+
+			if weight > remaining || value <= 0 {
+				continue
+			}
+
+			// Compare densities by cross-multiplying, which also copes with bundles
+			// that weigh nothing.
+			if best == nil || value*bestWeight > bestValue*weight ||
+				(value*bestWeight == bestValue*weight && value > bestValue) {
+				best, bestWeight, bestValue = bundle, weight, value
+			}
+		}
+
+		if best == nil {
+			break
+		}
+
+		for _, j := range best {
+			packed[j] = true
+			indices = append(indices, j)
+		}
+		remaining -= bestWeight
+	}
+
+	return indices, nil
+}
+
+// bundle returns `i` along with every one of its direct and indirect
+// dependencies that hasn't already been packed.
+func bundle(i int64, deps map[int64][]int64, packed []bool) []int64 {
+	seen := map[int64]bool{i: true}
+	stack := []int64{i}
+	var bundle []int64
+
+	for len(stack) > 0 {
+		j := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		bundle = append(bundle, j)
+
+		for _, d := range deps[j] {
+			if !seen[d] && !packed[d] {
+				seen[d] = true
+				stack = append(stack, d)
+			}
+		}
+	}
+
+	return bundle
+}
+
+// checkDependencies returns ErrIndexOutOfRange if `deps` refers to an index
+// outside of `n` items, or ErrCyclicDependency if it contains a cycle.
+func checkDependencies(n int, deps map[int64][]int64) error {
+	for i, ds := range deps {
+		if i < 0 || i >= int64(n) {
+			return ErrIndexOutOfRange
+		}
+		for _, d := range ds {
+			if d < 0 || d >= int64(n) {
+				return ErrIndexOutOfRange
+			}
+		}
+	}
+
+	// Depth-first search from every item, looking for an edge back to an item
+	// that is still being visited.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, n)
+
+	var visit func(i int64) error
+	visit = func(i int64) error {
+		state[i] = visiting
+		for _, d := range deps[i] {
+			switch state[d] {
+			case visiting:
+				return ErrCyclicDependency
+			case unvisited:
+				if err := visit(d); err != nil {
+					return err
+				}
+			}
+		}
+		state[i] = visited
+		return nil
+	}
+
+	for i := range state {
+		if state[i] == unvisited {
+			if err := visit(int64(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
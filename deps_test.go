@@ -0,0 +1,74 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackWithDeps(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 1,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+	}
+
+	// The first item can only be packed along with the second.
+	deps := map[int64][]int64{
+		0: {1},
+	}
+
+	indices, err := KnapsackWithDeps(items, deps, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var weight int64 = 0
+	packed := make(map[int64]bool)
+	for _, i := range indices {
+		weight += items[i].Weight()
+		packed[i] = true
+	}
+
+	if weight > 5 {
+		t.Errorf("Expected a weight of at most %d, got %d", 5, weight)
+	}
+	if packed[0] && !packed[1] {
+		t.Errorf("Expected item 1 to be packed along with item 0, got %v", indices)
+	}
+}
+
+func TestKnapsackWithDepsCycle(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 1,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	deps := map[int64][]int64{
+		0: {1},
+		1: {2},
+		2: {0},
+	}
+
+	if _, err := KnapsackWithDeps(items, deps, 5); err != ErrCyclicDependency {
+		t.Errorf("Expected %v, got %v", ErrCyclicDependency, err)
+	}
+
+	if _, err := KnapsackWithDeps(items, map[int64][]int64{0: {3}}, 5); err != ErrIndexOutOfRange {
+		t.Errorf("Expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+}
@@ -0,0 +1,76 @@
+package knapsack
+
+import (
+	"math"
+)
+
+// MinWeightForValue solves the dual of the Knapsack problem: rather than
+// packing as much value as possible within a capacity, it finds the lightest
+// set of items whose total value is at least `targetValue`. It returns the
+// indices of those items.
+//
+// The DP table is indexed by value instead of capacity, with `weights[i][t]`
+// storing the minimum weight of the first `i` items needed to reach a value
+// of at least `t`. Any value beyond the target is as good as the target
+// itself, so the table only needs `targetValue+1` columns, and this takes
+// O(N x targetValue) time and memory.
+//
+// ErrTargetUnreachable is returned if packing every item with a positive value
+// still falls short of the target.
+func MinWeightForValue(items []Packable, targetValue int64) ([]int64, error) {
+	if targetValue <= 0 {
+		return []int64{}, nil
+	}
+
+	weights := make([][]int64, len(items)+1)
+	keep := make([][]bool, len(items)+1)
+	for i := range weights {
+		weights[i] = make([]int64, targetValue+1)
+		keep[i] = make([]bool, targetValue+1)
+	}
+	for t := int64(1); t <= targetValue; t++ {
+		weights[0][t] = math.MaxInt64
+	}
+
+	for i := 1; i <= len(items); i++ {
+		w, v := items[i-1].Weight(), items[i-1].Value()
+
+		for t := int64(0); t <= targetValue; t++ {
+			weights[i][t] = weights[i-1][t]
+
+			// Items without a positive value can never help reach the target.
+			if v <= 0 {
+				continue
+			}
+
+			// Packing this item leaves `t-v` of the target still to reach.
+			rest := t - v
+			if rest < 0 {
+				rest = 0
+			}
+			if weights[i-1][rest] == math.MaxInt64 {
+				continue
+			}
+
+			if taken := w + weights[i-1][rest]; taken < weights[i][t] {
+				weights[i][t] = taken
+				keep[i][t] = true
+			}
+		}
+	}
+
+	if weights[len(items)][targetValue] == math.MaxInt64 {
+		return nil, ErrTargetUnreachable
+	}
+
+	t := targetValue
+	indices := []int64{}
+	for n := len(items); n > 0 && t > 0; n-- {
+		if keep[n][t] {
+			indices = append(indices, int64(n-1))
+			t -= items[n-1].Value()
+		}
+	}
+
+	return indices, nil
+}
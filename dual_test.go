@@ -0,0 +1,56 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestMinWeightForValue(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			5, 9,
+		},
+	}
+
+	// {0, 2} reaches 9 with a weight of 4, lighter than the fourth item alone.
+	indices, err := MinWeightForValue(items, 9)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var value, weight int64 = 0, 0
+	for _, i := range indices {
+		value += items[i].Value()
+		weight += items[i].Weight()
+	}
+
+	if value < 9 {
+		t.Errorf("Expected a value of at least %d, got %d", 9, value)
+	}
+	if weight != 4 {
+		t.Errorf("Expected %d, got %d", 4, weight)
+	}
+}
+
+func TestMinWeightForValueUnreachable(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+	}
+
+	if _, err := MinWeightForValue(items, 9); err != ErrTargetUnreachable {
+		t.Errorf("Expected %v, got %v", ErrTargetUnreachable, err)
+	}
+}
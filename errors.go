@@ -0,0 +1,73 @@
+package knapsack
+
+import "errors"
+
+var (
+	// ErrCapacityTooLarge is returned when the DP table for a capacity would be
+	// too large to allocate.
+	ErrCapacityTooLarge = errors.New("knapsack: capacity is too large to allocate a table for")
+
+	// ErrInvalidGroup is returned when a group of items refers to an index
+	// outside of the items, or an index appears in more than one group.
+	ErrInvalidGroup = errors.New("knapsack: group contains an invalid or repeated index")
+
+	// ErrCyclicDependency is returned when a set of item dependencies contains
+	// a cycle, so that no item in the cycle could ever be packed.
+	ErrCyclicDependency = errors.New("knapsack: dependencies contain a cycle")
+
+	// ErrIndexOutOfRange is returned when an index doesn't refer to one of the
+	// items.
+	ErrIndexOutOfRange = errors.New("knapsack: index out of range")
+
+	// ErrInfeasible is returned when no set of items satisfies every
+	// constraint of a problem.
+	ErrInfeasible = errors.New("knapsack: no set of items satisfies the constraints")
+
+	// ErrInvalidDecay is returned when a decay factor is outside of the range
+	// (0, 1].
+	ErrInvalidDecay = errors.New("knapsack: decay must be greater than 0 and at most 1")
+
+	// ErrInvalidEpsilon is returned when an approximation is asked for with an
+	// error bound outside of the range (0, 1).
+	ErrInvalidEpsilon = errors.New("knapsack: epsilon must be between 0 and 1")
+
+	// ErrInvalidPercent is returned when a percentage is outside of the range
+	// [0, 100].
+	ErrInvalidPercent = errors.New("knapsack: percent must be between 0 and 100")
+
+	// ErrInvalidProbability is returned when an item is given a probability
+	// outside of the range [0, 1].
+	ErrInvalidProbability = errors.New("knapsack: probability must be between 0 and 1")
+
+	// ErrLengthMismatch is returned when a slice that should hold one entry
+	// per item has a different length to the items themselves.
+	ErrLengthMismatch = errors.New("knapsack: slice length does not match the number of items")
+
+	// ErrNegativeCapacity is returned when a knapsack is given a negative
+	// capacity.
+	ErrNegativeCapacity = errors.New("knapsack: capacity is negative")
+
+	// ErrNegativeWeight is returned when an item has a negative weight, as the
+	// solvers assume that packing an item never frees up capacity.
+	ErrNegativeWeight = errors.New("knapsack: item has a negative weight")
+
+	// ErrNilItem is returned when the items to pack include a nil item.
+	ErrNilItem = errors.New("knapsack: item is nil")
+
+	// ErrOverflow is returned when the total value of a set of items is too
+	// large to be represented by an int64.
+	ErrOverflow = errors.New("knapsack: total value overflows int64")
+
+	// ErrRequiredExceedsCapacity is returned when the items that must be
+	// packed don't fit within capacity on their own.
+	ErrRequiredExceedsCapacity = errors.New("knapsack: required items exceed capacity")
+
+	// ErrTargetUnreachable is returned when even packing every item can't
+	// reach a target value.
+	ErrTargetUnreachable = errors.New("knapsack: target value is unreachable")
+
+	// ErrZeroWeightItem is returned by solvers that allow an item to be packed
+	// more than once when an item with a positive value has no weight, as such
+	// an item could be packed an infinite number of times.
+	ErrZeroWeightItem = errors.New("knapsack: item with a positive value has no weight")
+)
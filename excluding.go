@@ -0,0 +1,34 @@
+package knapsack
+
+// KnapsackExcluding behaves like Knapsack, except that the items at the
+// `excluded` indices are never packed, as if they had been removed from
+// `items`. The returned indices still refer to positions in `items`, in the
+// same order as Knapsack, so they stay stable however the items are filtered.
+// Excluded indices that don't refer to an item have nothing to exclude, and
+// are ignored.
+func KnapsackExcluding(items []Packable, excluded []int64, capacity int64) []int64 {
+	isExcluded := make([]bool, len(items))
+	for _, i := range excluded {
+		if i >= 0 && i < int64(len(items)) {
+			isExcluded[i] = true
+		}
+	}
+
+	// Solve for the remaining items, keeping track of where each of them came
+	// from.
+	var remaining []Packable
+	var original []int64
+	for i, item := range items {
+		if !isExcluded[i] {
+			remaining = append(remaining, item)
+			original = append(original, int64(i))
+		}
+	}
+
+	indices := Knapsack(remaining, capacity)
+	for i, index := range indices {
+		indices[i] = original[index]
+	}
+
+	return indices
+}
@@ -0,0 +1,36 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackExcluding(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	for _, tc := range []struct {
+		excluded []int64
+		expected []int64
+	}{
+		{nil, []int64{2, 0}},
+		{[]int64{0}, []int64{2, 1}},
+		{[]int64{2}, []int64{1, 0}},
+		{[]int64{0, 1, 2}, []int64{}},
+		// Indices that don't refer to an item are ignored.
+		{[]int64{-1, 3}, []int64{2, 0}},
+	} {
+		if indices := KnapsackExcluding(items, tc.excluded, 5); !reflect.DeepEqual(indices, tc.expected) {
+			t.Errorf("%v: Expected %v, got %v", tc.excluded, tc.expected, indices)
+		}
+	}
+}
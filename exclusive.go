@@ -0,0 +1,78 @@
+package knapsack
+
+// KnapsackExclusive behaves like Knapsack, except that at most one item from
+// each of `groups` may be packed. Each group lists the indices of items that
+// are mutually exclusive; items that don't appear in any group are
+// unconstrained. It returns the indices of the items to pack.
+//
+// This is solved as a multiple-choice knapsack: each group, along with each
+// ungrouped item, becomes a single row of the DP table, and a cell takes the
+// best of leaving the row out or packing any one of its items. That keeps the
+// runtime at O(N x M), the same as Knapsack, while the table only needs a row
+// per group rather than per item.
+//
+// ErrInvalidGroup is returned if a group contains an index that is out of
+// range, or if an index appears more than once across the groups.
+func KnapsackExclusive(items []Packable, groups [][]int64, capacity int64) ([]int64, error) {
+	grouped := make([]bool, len(items))
+	for _, group := range groups {
+		for _, i := range group {
+			if i < 0 || i >= int64(len(items)) || grouped[i] {
+				return nil, ErrInvalidGroup
+			}
+			grouped[i] = true
+		}
+	}
+
+	if capacity < 0 {
+		return []int64{}, nil
+	}
+
+	// Every ungrouped item forms a group of its own.
+	rows := make([][]int64, 0, len(groups)+len(items))
+	rows = append(rows, groups...)
+	for i, g := range grouped {
+		if !g {
+			rows = append(rows, []int64{int64(i)})
+		}
+	}
+
+	// `values[g][c]` stores the best value using the first `g` rows at a
+	// capacity of `c`, and `chosen[g][c]` stores the index of the item packed
+	// from row `g`, or -1 if none is.
+	values := make([][]int64, len(rows)+1)
+	chosen := make([][]int64, len(rows)+1)
+	for g := range values {
+		values[g] = make([]int64, capacity+1)
+		chosen[g] = make([]int64, capacity+1)
+	}
+
+	for g := 1; g <= len(rows); g++ {
+		for c := int64(0); c <= capacity; c++ {
+			values[g][c] = values[g-1][c]
+			chosen[g][c] = -1
+
+			for _, i := range rows[g-1] {
+				w := items[i].Weight()
+				if w > c {
+					continue
+				}
+				if v := items[i].Value() + values[g-1][c-w]; v > values[g][c] {
+					values[g][c] = v
+					chosen[g][c] = i
+				}
+			}
+		}
+	}
+
+	c := capacity
+	indices := []int64{}
+	for g := len(rows); g > 0; g-- {
+		if i := chosen[g][c]; i != -1 {
+			indices = append(indices, i)
+			c -= items[i].Weight()
+		}
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,59 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackExclusive(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			2, 4,
+		},
+	}
+
+	// Without the group, we'd pack the first, third and fourth items.
+	indices, err := KnapsackExclusive(items, [][]int64{{2, 3}}, 6)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var value int64 = 0
+	packed := make(map[int64]bool)
+	for _, i := range indices {
+		value += items[i].Value()
+		packed[i] = true
+	}
+
+	if packed[2] && packed[3] {
+		t.Errorf("Expected at most one of items 2 and 3, got %v", indices)
+	}
+	if value != 12 {
+		t.Errorf("Expected %d, got %d", 12, value)
+	}
+}
+
+func TestKnapsackExclusiveInvalidGroup(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+	}
+
+	for _, groups := range [][][]int64{{{0, 2}}, {{0, 1}, {1}}, {{-1}}} {
+		if _, err := KnapsackExclusive(items, groups, 5); err != ErrInvalidGroup {
+			t.Errorf("Expected %v, got %v", ErrInvalidGroup, err)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package knapsack
+
+// probabilityPrecision is the number of decimal places of the expected
+// values that KnapsackExpected keeps when scaling them to integers.
+const probabilityPrecision = 6
+
+// KnapsackExpected packs items that only pay off with some probability, where
+// item `i` is worth its value with probability `prob[i]` and nothing
+// otherwise. It returns the indices of the items to pack that maximise the
+// total expected value, `Value() * prob[i]`, within the given capacity, in the
+// same order as Knapsack. The weight of every packed item counts against the
+// capacity whether or not it pays off.
+//
+// The DP needs integer values, so each expected value is scaled to an integer
+// with ScaleToInt, keeping six decimal places, and expected values that
+// differ by less than that are treated as equal. Item values must be small
+// enough that the scaled values fit in an int64.
+//
+// ErrLengthMismatch is returned if `prob` does not have one entry per item,
+// and an *ItemError wrapping ErrInvalidProbability for the first probability
+// outside of the range [0, 1].
+func KnapsackExpected(items []Packable, prob []float64, capacity int64) ([]int64, error) {
+	if len(prob) != len(items) {
+		return nil, ErrLengthMismatch
+	}
+
+	raw := make([]float64, len(items))
+	for i, p := range prob {
+		if !(p >= 0 && p <= 1) {
+			return nil, &ItemError{
+				Index: int64(i),
+				Err:   ErrInvalidProbability,
+			}
+		}
+		raw[i] = float64(items[i].Value()) * p
+	}
+	scaled, _ := ScaleToInt(raw, probabilityPrecision)
+
+	expected := make([]Packable, len(items))
+	for i := range expected {
+		expected[i] = item{
+			weight: items[i].Weight(),
+			value:  scaled[i],
+		}
+	}
+
+	return Knapsack(expected, capacity), nil
+}
@@ -0,0 +1,58 @@
+package knapsack
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackExpected(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 10,
+		},
+		TestKnapsackItem{
+			2, 6,
+		},
+		TestKnapsackItem{
+			2, 4,
+		},
+	}
+
+	// The first item is the most valuable, but is only worth 2.5 on average,
+	// less than either of the others.
+	indices, err := KnapsackExpected(items, []float64{0.25, 0.9, 1}, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []int64{2, 1}
+	if !reflect.DeepEqual(indices, expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+}
+
+func TestKnapsackExpectedInvalidInput(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 10,
+		},
+		TestKnapsackItem{
+			2, 6,
+		},
+	}
+
+	if _, err := KnapsackExpected(items, []float64{0.5}, 4); err != ErrLengthMismatch {
+		t.Errorf("Expected %v, got %v", ErrLengthMismatch, err)
+	}
+
+	for _, p := range []float64{-0.1, 1.5, math.NaN()} {
+		_, err := KnapsackExpected(items, []float64{0.5, p}, 4)
+
+		var itemErr *ItemError
+		if !errors.Is(err, ErrInvalidProbability) || !errors.As(err, &itemErr) || itemErr.Index != 1 {
+			t.Errorf("%v: Expected %v for item %d, got %v", p, ErrInvalidProbability, 1, err)
+		}
+	}
+}
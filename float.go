@@ -0,0 +1,121 @@
+package knapsack
+
+import (
+	"math"
+)
+
+// A FloatPackable item is one whose weight and value are naturally fractional,
+// such as a price or a mass in kilograms.
+type FloatPackable interface {
+	Weight() float64
+	Value() float64
+}
+
+// KnapsackFloat solves the 0/1 Knapsack problem for items with fractional
+// weights and values. It does so by scaling every weight and value, along
+// with the capacity, to integers, preserving `precision` decimal places, and
+// then packing the scaled items with Knapsack. It returns the indices of the
+// items to pack.
+//
+// The values are scaled with ScaleToInt. So that the packed items never weigh
+// more than the capacity, the weights are rounded up to the next unit and the
+// capacity down instead, unless they're within floating-point error of a
+// whole unit already. Any that are too large for an int64 are treated as
+// math.MaxInt64, and a capacity more than the items weigh in total is treated
+// as that total, which packs the same items.
+//
+// Be careful when choosing `precision`: the DP table has a column for every
+// unit of scaled capacity, so each extra decimal place multiplies both the
+// runtime and memory of the solve by 10.
+func KnapsackFloat(items []FloatPackable, capacity float64, precision int) []int {
+	if capacity < 0 {
+		return []int{}
+	}
+
+	weights := make([]float64, len(items))
+	values := make([]float64, len(items))
+	for i, p := range items {
+		weights[i], values[i] = p.Weight(), p.Value()
+	}
+	scaled, scaledCapacity := scaleItems(weights, values, capacity, precision)
+
+	packed := Knapsack(scaled, scaledCapacity)
+	indices := make([]int, len(packed))
+	for i, index := range packed {
+		indices[i] = int(index)
+	}
+
+	return indices
+}
+
+// scaleItems scales fractional weights, values and a capacity to the integer
+// items and capacity that KnapsackFloat packs, as it describes.
+func scaleItems(weights, values []float64, capacity float64, precision int) ([]Packable, int64) {
+	ints, factor := ScaleToInt(values, precision)
+
+	scaled := make([]Packable, len(weights))
+	for i, w := range weights {
+		scaled[i] = item{
+			weight: scaleBound(w, factor, true),
+			value:  ints[i],
+		}
+	}
+
+	return scaled, boundCapacity(scaled, scaleBound(capacity, factor, false))
+}
+
+// scaleBound multiplies `v` by `factor` and rounds it to an integer, up if
+// `up` is true and down otherwise. A product within floating-point error of
+// an integer is rounded to that integer, so that e.g. 0.07 at a precision of
+// 2 scales to 7 even though `0.07 * 100` is slightly more than 7. A product
+// beyond the range of an int64 is saturated to math.MaxInt64 or
+// math.MinInt64.
+func scaleBound(v float64, factor int64, up bool) int64 {
+	scaled := v * float64(factor)
+	if scaled >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	if scaled <= math.MinInt64 {
+		return math.MinInt64
+	}
+	if nearest := math.RoundToEven(scaled); math.Abs(scaled-nearest) <= 1e-9*math.Max(1, math.Abs(scaled)) {
+		return int64(nearest)
+	}
+	if up {
+		return int64(math.Ceil(scaled))
+	}
+	return int64(math.Floor(scaled))
+}
+
+// maxPrecision is the largest precision that ScaleToInt can use, since
+// 10^19 doesn't fit in an int64.
+const maxPrecision = 18
+
+// ScaleToInt scales fractional values to integers, preserving `precision`
+// decimal places, so that they can be packed by the integer solvers. It
+// returns each value multiplied by 10^precision and rounded to the nearest
+// integer, with halves rounded to even so that rounding errors don't
+// accumulate in one direction, along with the factor 10^precision itself.
+// Negative values are scaled in the same way as positive ones. A precision
+// less than 0 is treated as 0, and one greater than 18 as 18, so that the
+// factor always fits in an int64; the scaled values must fit too.
+func ScaleToInt(vals []float64, precision int) ([]int64, int64) {
+	if precision < 0 {
+		precision = 0
+	}
+	if precision > maxPrecision {
+		precision = maxPrecision
+	}
+
+	factor := int64(1)
+	for i := 0; i < precision; i++ {
+		factor *= 10
+	}
+
+	scaled := make([]int64, len(vals))
+	for i, v := range vals {
+		scaled[i] = int64(math.RoundToEven(v * float64(factor)))
+	}
+
+	return scaled, factor
+}
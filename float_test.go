@@ -0,0 +1,102 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+type TestFloatItem struct {
+	weight float64
+	value  float64
+}
+
+func (i TestFloatItem) Weight() float64 {
+	return i.weight
+}
+
+func (i TestFloatItem) Value() float64 {
+	return i.value
+}
+
+func TestKnapsackFloat(t *testing.T) {
+	items := []FloatPackable{
+		TestFloatItem{
+			1.5, 2.25,
+		},
+		TestFloatItem{
+			0.75, 1.5,
+		},
+		TestFloatItem{
+			0.8, 1.4,
+		},
+	}
+
+	indices := KnapsackFloat(items, 2.3, 2)
+	var value float64 = 0
+	for _, i := range indices {
+		value += items[i].Value()
+	}
+
+	if value != 3.75 {
+		t.Errorf("Expected %f, got %f", 3.75, value)
+	}
+
+	// With no decimal places, every item weighs at least 1 and only two of
+	// them fit.
+	indices = KnapsackFloat(items, 2.3, 0)
+	if len(indices) != 2 {
+		t.Errorf("Expected 2 items, got %v", indices)
+	}
+}
+
+func TestKnapsackFloatWithinCapacity(t *testing.T) {
+	for _, tc := range []struct {
+		weights   []float64
+		capacity  float64
+		precision int
+		expected  []int
+	}{
+		// Rounded to the nearest hundredth, all three would fit, but they weigh
+		// 1.002 together.
+		{[]float64{0.334, 0.334, 0.334}, 1, 2, []int{1, 0}},
+		{[]float64{3.6}, 3.5, 0, []int{}},
+		// Weights that fit exactly still fit, despite floating-point error.
+		{[]float64{0.07, 0.93}, 1, 2, []int{1, 0}},
+		// Scaled weights and capacities too large for an int64 are saturated.
+		{[]float64{1}, 1e30, 2, []int{0}},
+		{[]float64{1e30, 1}, 1, 2, []int{1}},
+	} {
+		items := make([]FloatPackable, len(tc.weights))
+		for i, w := range tc.weights {
+			items[i] = TestFloatItem{w, 1}
+		}
+
+		indices := KnapsackFloat(items, tc.capacity, tc.precision)
+		if !reflect.DeepEqual(indices, tc.expected) {
+			t.Errorf("%v in %v: expected %v, got %v", tc.weights, tc.capacity, tc.expected, indices)
+		}
+	}
+}
+
+func TestScaleToInt(t *testing.T) {
+	for _, tc := range []struct {
+		vals      []float64
+		precision int
+		expected  []int64
+		factor    int64
+	}{
+		{[]float64{1.234, 0, -1.234}, 2, []int64{123, 0, -123}, 100},
+		// Halves are rounded to even, whatever their sign.
+		{[]float64{0.5, 1.5, 2.5, -0.5, -1.5, -2.5}, 0, []int64{0, 2, 2, 0, -2, -2}, 1},
+		{[]float64{0.125, 0.375}, 2, []int64{12, 38}, 100},
+		// Precisions outside of what an int64 factor can hold are clamped.
+		{[]float64{7.6}, -1, []int64{8}, 1},
+		{[]float64{0}, 30, []int64{0}, 1e18},
+		{nil, 3, []int64{}, 1000},
+	} {
+		scaled, factor := ScaleToInt(tc.vals, tc.precision)
+		if !reflect.DeepEqual(scaled, tc.expected) || factor != tc.factor {
+			t.Errorf("%v at %d: expected %v and %d, got %v and %d", tc.vals, tc.precision, tc.expected, tc.factor, scaled, factor)
+		}
+	}
+}
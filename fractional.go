@@ -0,0 +1,36 @@
+package knapsack
+
+// FractionalKnapsack solves the fractional variant of the Knapsack problem, in
+// which any fraction of an item may be packed, such as when packing liquids or
+// bulk materials. It returns a map of item index to the fraction (0.0 - 1.0)
+// of that item to pack; items that should not be packed are left out of the
+// map.
+//
+// The fractional problem can be solved greedily: items are packed whole in
+// order of descending value density (value per unit of weight) until the next
+// item no longer fits, at which point as much of it as will fit is packed.
+// Note that the result is only optimal for the fractional problem, and is not
+// a solution to the 0/1 problem solved by Knapsack.
+func FractionalKnapsack(items []Packable, capacity int64) map[int64]float64 {
+	fractions := make(map[int64]float64)
+
+	// Items without a positive value never improve the solution, and items
+	// that cost nothing to pack come first and are always packed whole.
+	remaining := capacity
+	for _, i := range SortByDensity(items) {
+		w := items[i].Weight()
+		if items[i].Value() <= 0 || w < 0 {
+			continue
+		}
+
+		if w == 0 || w <= remaining {
+			fractions[i] = 1.0
+			remaining -= w
+		} else if remaining > 0 {
+			fractions[i] = float64(remaining) / float64(w)
+			remaining = 0
+		}
+	}
+
+	return fractions
+}
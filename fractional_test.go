@@ -0,0 +1,39 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestFractionalKnapsack(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			10, 60,
+		},
+		TestKnapsackItem{
+			20, 100,
+		},
+		TestKnapsackItem{
+			30, 120,
+		},
+		TestKnapsackItem{
+			0, 5,
+		},
+	}
+
+	fractions := FractionalKnapsack(items, 50)
+
+	var value float64 = 0
+	for i, f := range fractions {
+		value += float64(items[i].Value()) * f
+	}
+
+	if value != 245 {
+		t.Errorf("Expected %f, got %f", 245.0, value)
+	}
+	if fractions[2] != 2.0/3.0 {
+		t.Errorf("Expected %f, got %f", 2.0/3.0, fractions[2])
+	}
+	if fractions[3] != 1.0 {
+		t.Errorf("Expected %f, got %f", 1.0, fractions[3])
+	}
+}
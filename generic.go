@@ -0,0 +1,28 @@
+package knapsack
+
+// Integer is a constraint that permits any integer type, so that callers can
+// use whichever integer type is most natural for their weights and values.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// A GenericPackable item is one that can be placed in a Knapsack, with a
+// weight and value of any integer type T.
+type GenericPackable[T Integer] interface {
+	Weight() T
+	Value() T
+}
+
+// KnapsackOf is a generic version of Knapsack, for items whose weights and
+// values are naturally some integer type other than int64. It returns the
+// indices of the items to pack, just like KnapsackNumber does for the same
+// items.
+func KnapsackOf[T Integer](items []GenericPackable[T], capacity T) []int {
+	numbers := make([]NumberPackable[T], len(items))
+	for i, p := range items {
+		numbers[i] = p
+	}
+
+	return KnapsackNumber(numbers, capacity, 0)
+}
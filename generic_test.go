@@ -0,0 +1,120 @@
+package knapsack
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+type TestGenericItem[T Integer] struct {
+	weight T
+	value  T
+}
+
+func (i TestGenericItem[T]) Weight() T {
+	return i.weight
+}
+
+func (i TestGenericItem[T]) Value() T {
+	return i.value
+}
+
+func TestKnapsackOf(t *testing.T) {
+	items := []GenericPackable[int32]{
+		TestGenericItem[int32]{
+			3, 5,
+		},
+		TestGenericItem[int32]{
+			2, 3,
+		},
+		TestGenericItem[int32]{
+			1, 4,
+		},
+	}
+
+	indices := KnapsackOf(items, int32(5))
+	var value int32 = 0
+	for _, i := range indices {
+		value += items[i].Value()
+	}
+
+	if value != 9 {
+		t.Errorf("Expected %d, got %d", 9, value)
+	}
+}
+
+func TestKnapsackOfUnsigned(t *testing.T) {
+	items := []GenericPackable[uint]{
+		TestGenericItem[uint]{
+			3, 5,
+		},
+		TestGenericItem[uint]{
+			2, 3,
+		},
+		TestGenericItem[uint]{
+			1, 4,
+		},
+	}
+
+	indices := KnapsackOf(items, uint(0))
+	if len(indices) != 0 {
+		t.Errorf("Expected no items, got %v", indices)
+	}
+
+	indices = KnapsackOf(items, uint(3))
+	var value uint = 0
+	for _, i := range indices {
+		value += items[i].Value()
+	}
+
+	if value != 7 {
+		t.Errorf("Expected %d, got %d", 7, value)
+	}
+}
+
+func TestKnapsackOfMaxCapacity(t *testing.T) {
+	items := []GenericPackable[uint8]{
+		TestGenericItem[uint8]{
+			200, 5,
+		},
+		TestGenericItem[uint8]{
+			55, 3,
+		},
+	}
+
+	indices := KnapsackOf(items, uint8(255))
+	if len(indices) != 2 {
+		t.Errorf("Expected both items, got %v", indices)
+	}
+}
+
+func TestKnapsackOfNegativeCapacity(t *testing.T) {
+	items := []GenericPackable[int8]{
+		TestGenericItem[int8]{
+			1, 5,
+		},
+	}
+
+	indices := KnapsackOf(items, int8(-1))
+	if len(indices) != 0 {
+		t.Errorf("Expected no items, got %v", indices)
+	}
+}
+
+func TestKnapsackOfUint64(t *testing.T) {
+	items := []GenericPackable[uint64]{
+		TestGenericItem[uint64]{
+			1 << 63, 5,
+		},
+		TestGenericItem[uint64]{
+			2, 3,
+		},
+		TestGenericItem[uint64]{
+			1, 4,
+		},
+	}
+
+	if indices := KnapsackOf(items, uint64(math.MaxUint64)); !reflect.DeepEqual(indices, []int{2, 1}) {
+		t.Errorf("Expected %v, got %v", []int{2, 1}, indices)
+	}
+}
@@ -0,0 +1,51 @@
+package knapsack
+
+import (
+	"sort"
+)
+
+// KnapsackGreedy approximates the 0/1 Knapsack problem in O(N log N) time,
+// for inputs that are too large for the DP used by Knapsack. It returns the
+// indices of the items to pack, in ascending order.
+//
+// Items are packed in order of descending value density, skipping any item
+// that no longer fits. On its own this can be arbitrarily bad (think of a
+// small, dense item that leaves no room for a huge, valuable one), so the
+// result is compared against packing only the single most valuable item that
+// fits and the better of the two is returned. This is guaranteed to be worth
+// at least half of the optimal value.
+func KnapsackGreedy(items []Packable, capacity int64) []int64 {
+	greedy := []int64{}
+	var greedyValue int64 = 0
+	remaining := capacity
+
+	for _, i := range SortByDensity(items) {
+		w, v := items[i].Weight(), items[i].Value()
+		if v <= 0 || w < 0 || w > remaining {
+			continue
+		}
+
+		greedy = append(greedy, i)
+		greedyValue += v
+		remaining -= w
+	}
+
+	best := int64(-1)
+	for i, item := range items {
+		if item.Weight() < 0 || item.Weight() > capacity || item.Value() <= 0 {
+			continue
+		}
+		if best == -1 || item.Value() > items[best].Value() {
+			best = int64(i)
+		}
+	}
+
+	if best != -1 && items[best].Value() > greedyValue {
+		return []int64{best}
+	}
+
+	sort.Slice(greedy, func(a, b int) bool {
+		return greedy[a] < greedy[b]
+	})
+	return greedy
+}
@@ -0,0 +1,47 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackGreedy(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	indices := KnapsackGreedy(items, 5)
+	var value int64 = 0
+	for _, i := range indices {
+		value += items[i].Value()
+	}
+
+	if value != 9 {
+		t.Errorf("Expected %d, got %d", 9, value)
+	}
+}
+
+func TestKnapsackGreedySingleItem(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 2,
+		},
+		TestKnapsackItem{
+			10, 10,
+		},
+	}
+
+	// Packing by density takes the small item, which leaves no room for the
+	// far more valuable large one.
+	indices := KnapsackGreedy(items, 10)
+	if len(indices) != 1 || indices[0] != 1 {
+		t.Errorf("Expected %v, got %v", []int64{1}, indices)
+	}
+}
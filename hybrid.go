@@ -0,0 +1,17 @@
+package knapsack
+
+// HybridKnapsack packs a Knapsack with two kinds of item: `indivisible` items,
+// which must be packed whole or not at all as in Knapsack, and `divisible`
+// items, any fraction of which may be packed as in FractionalKnapsack. It
+// returns the indices of the indivisible items to pack, and a map of the
+// indices of the divisible items to the fraction (0.0 - 1.0) of each to pack.
+//
+// The indivisible items are packed first, as Knapsack would pack them, and
+// whatever capacity they leave is then filled with the divisible items in
+// order of value density. This is quick, but it isn't guaranteed to be
+// optimal: it can be better to leave out an indivisible item to make room
+// for more of a dense divisible one.
+func HybridKnapsack(indivisible []Packable, divisible []Packable, capacity int64) ([]int64, map[int64]float64) {
+	s, _ := Solve(indivisible, capacity)
+	return s.Indices, FractionalKnapsack(divisible, s.RemainingCapacity)
+}
@@ -0,0 +1,52 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestHybridKnapsack(t *testing.T) {
+	indivisible := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			4, 3,
+		},
+	}
+	divisible := []Packable{
+		TestKnapsackItem{
+			4, 4,
+		},
+	}
+
+	// Only the first indivisible item fits alongside anything else, leaving
+	// room for half of the divisible item.
+	indices, fractions := HybridKnapsack(indivisible, divisible, 5)
+	if len(indices) != 1 || indices[0] != 0 {
+		t.Errorf("Expected [0], got %v", indices)
+	}
+	if len(fractions) != 1 || fractions[0] != 0.5 {
+		t.Errorf("Expected map[0:0.5], got %v", fractions)
+	}
+}
+
+func TestHybridKnapsackNoSpareCapacity(t *testing.T) {
+	indivisible := []Packable{
+		TestKnapsackItem{
+			5, 5,
+		},
+	}
+	divisible := []Packable{
+		TestKnapsackItem{
+			4, 4,
+		},
+	}
+
+	indices, fractions := HybridKnapsack(indivisible, divisible, 5)
+	if len(indices) != 1 || indices[0] != 0 {
+		t.Errorf("Expected [0], got %v", indices)
+	}
+	if len(fractions) != 0 {
+		t.Errorf("Expected no fractions, got %v", fractions)
+	}
+}
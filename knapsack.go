@@ -1,5 +1,11 @@
 package knapsack
 
+import (
+	"context"
+	"math"
+	"sort"
+)
+
 // A Packable item is one that can be placed in a Knapsack
 // It must implement a Weight() and a Value() function in order to determine
 // whether or not the item should be packed or not.
@@ -8,87 +14,571 @@ type Packable interface {
 	Value() int64
 }
 
+// item is a simple Packable used internally when a solver needs to build
+// its own items, e.g. when transforming a problem into a 0/1 Knapsack.
+type item struct {
+	weight int64
+	value  int64
+}
+
+func (i item) Weight() int64 {
+	return i.weight
+}
+
+func (i item) Value() int64 {
+	return i.value
+}
+
 // Knapsack uses a dynamic programming pattern to calculate the maximum value
 // to be gained from an array of items whilst keeping the total weight of items
 // less than or equal to a capacity. It will return the indices of the items
-// to pack.
+// to pack. If there are no items, or none of them can be packed (including
+// when `capacity` is negative), an empty (non-nil) slice is returned.
+//
+// When several sets of items are worth the same maximum value, the one that
+// is returned is decided by a fixed rule: whenever packing an item would give
+// exactly the same value as leaving it out, the item is left out. In
+// particular, an item without a positive value is never packed. Because the
+// rule is applied to each item in turn, it favours the items that come
+// earlier in `items`, but it doesn't guarantee the fewest items overall.
+//
+// The rule only looks at the weights and values of the items, never at the
+// items themselves, so the result is deterministic: identical items (those
+// with the same weight and value) are interchangeable, and reordering them
+// among themselves never changes the indices returned. When only some copies
+// of an identical item are packed, they are always the copies that come
+// first in `items`.
 // For a very good guide to the 0/1 Knapsack Problem, see: https://www.youtube.com/watch?v=EH6h7WA7sDw
 func Knapsack(items []Packable, capacity int64) []int64 {
+	indices, _, _ := knapsack(defaultConfig(), items, capacity)
+	return indices
+}
+
+// KnapsackWithValue behaves exactly like Knapsack but also returns the
+// maximum value that the packed items add up to, saving callers from having
+// to sum the values of the returned indices themselves.
+func KnapsackWithValue(items []Packable, capacity int64) ([]int64, int64) {
+	indices, value, _ := knapsack(defaultConfig(), items, capacity)
+	return indices, value
+}
+
+// KnapsackContext behaves like Knapsack, but gives up on the calculation if
+// `ctx` is canceled or its deadline is exceeded, returning `ctx.Err()`. The
+// context is checked once for every item, before its row of the DP table is
+// calculated.
+func KnapsackContext(ctx context.Context, items []Packable, capacity int64) ([]int64, error) {
+	cfg := defaultConfig()
+	cfg.ctx = ctx
+
+	indices, _, err := knapsack(cfg, items, capacity)
+	if err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
+
+// KnapsackProgress behaves like Knapsack, but calls `onRow` each time a row of
+// the DP table has been calculated, with the number of rows (items) done so
+// far and the total number of rows, so that callers can report the progress
+// of a long solve. `onRow` is called from the solving goroutine and may be
+// nil, in which case no progress is reported.
+func KnapsackProgress(items []Packable, capacity int64, onRow func(done, total int)) []int64 {
+	cfg := defaultConfig()
+	cfg.onRow = onRow
+
+	indices, _, _ := knapsack(cfg, items, capacity)
+	return indices
+}
+
+// KnapsackProgressChan behaves like KnapsackProgress, but solves in a new
+// goroutine and reports its progress over channels. The first channel receives
+// the number of rows (items) done so far each time a row of the DP table has
+// been calculated, and is closed once the table is complete. The second then
+// receives the indices of the items to pack, as Knapsack would return them,
+// and is closed too. Both channels are buffered, so the solve never waits for
+// the caller to receive from them.
+func KnapsackProgressChan(items []Packable, capacity int64) (<-chan int, <-chan []int64) {
+	progress := make(chan int, len(items))
+	result := make(chan []int64, 1)
+
+	go func() {
+		indices := KnapsackProgress(items, capacity, func(done, total int) {
+			progress <- done
+		})
+		close(progress)
+
+		result <- indices
+		close(result)
+	}()
+
+	return progress, result
+}
+
+// KnapsackChecked behaves like Knapsack, but validates its input and returns
+// an error rather than panicking or returning a corrupt solution. It returns
+// any error reported by Validate, and ErrOverflow if the sum of the values of
+// any combination of items it considers overflows an int64.
+func KnapsackChecked(items []Packable, capacity int64) ([]int64, error) {
+	if err := Validate(items, capacity); err != nil {
+		return nil, err
+	}
+
+	cfg := defaultConfig()
+	cfg.checkOverflow = true
+
+	indices, _, err := knapsack(cfg, items, capacity)
+	if err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
+
+// KnapsackSorted behaves exactly like Knapsack, but guarantees that the
+// indices are returned in ascending order, which makes solutions easier to
+// compare.
+func KnapsackSorted(items []Packable, capacity int64) []int64 {
+	cfg := defaultConfig()
+	cfg.sortIndices = true
+
+	indices, _, _ := knapsack(cfg, items, capacity)
+	return indices
+}
+
+// KnapsackByValue behaves like Knapsack, but returns the indices ordered by
+// the items' values, most valuable first. Items of equal value are ordered by
+// index, so the order is the same every time, which suits displaying them.
+func KnapsackByValue(items []Packable, capacity int64) []int64 {
+	indices := KnapsackSorted(items, capacity)
+
+	values := make(map[int64]int64, len(indices))
+	for _, i := range indices {
+		values[i] = items[i].Value()
+	}
+
+	sort.SliceStable(indices, func(a, b int) bool {
+		return values[indices[a]] > values[indices[b]]
+	})
+
+	return indices
+}
+
+// KnapsackSplit behaves like Knapsack, but returns the indices of the items
+// that were left out as well as those that were packed, each in ascending
+// order. Every index appears in exactly one of the two.
+func KnapsackSplit(items []Packable, capacity int64) (packed []int64, skipped []int64) {
+	packed = KnapsackSorted(items, capacity)
+	skipped = make([]int64, 0, len(items)-len(packed))
+
+	// Both lists are in ascending order, so we can walk the packed indices
+	// alongside every index.
+	p := 0
+	for i := int64(0); i < int64(len(items)); i++ {
+		if p < len(packed) && packed[p] == i {
+			p++
+			continue
+		}
+		skipped = append(skipped, i)
+	}
+
+	return packed, skipped
+}
+
+// KnapsackMinWeight behaves like Knapsack, but when several combinations of
+// items are worth the same maximum value, it returns the one that weighs the
+// least, leaving as much spare capacity as possible.
+func KnapsackMinWeight(items []Packable, capacity int64) []int64 {
+	cfg := defaultConfig()
+	cfg.tieBreak = tieMinWeight
+
+	indices, _, _ := knapsack(cfg, items, capacity)
+	return indices
+}
+
+// KnapsackFillBias behaves like Knapsack, but when several combinations of
+// items are worth the same maximum value, it returns the one that weighs the
+// most, leaving as little unused capacity as possible. It's the mirror image
+// of KnapsackMinWeight.
+func KnapsackFillBias(items []Packable, capacity int64) []int64 {
+	cfg := defaultConfig()
+	cfg.tieBreak = tieMaxWeight
+
+	indices, _, _ := knapsack(cfg, items, capacity)
+	return indices
+}
+
+// KnapsackFewestItems behaves like Knapsack, but when several combinations of
+// items are worth the same maximum value, it returns the one that packs the
+// fewest items.
+func KnapsackFewestItems(items []Packable, capacity int64) []int64 {
+	cfg := defaultConfig()
+	cfg.tieBreak = tieFewestItems
+
+	indices, _, _ := knapsack(cfg, items, capacity)
+	return indices
+}
+
+// KnapsackTable returns the matrix of values that Knapsack builds to find the
+// items to pack, for those who want to see how the solution comes about, such
+// as when teaching the algorithm. `table[i][c]` holds the maximum value that
+// can be gained from the first `i` items within a capacity of `c`, so the
+// matrix has `len(items)+1` rows of `capacity+1` values, and the optimal value
+// overall is `table[len(items)][capacity]`. A negative capacity has no
+// columns, so an empty matrix is returned.
+func KnapsackTable(items []Packable, capacity int64) [][]int64 {
+	if capacity < 0 {
+		return [][]int64{}
+	}
+
+	itemWeights, itemValues := snapshot(items)
+	values, _, _ := fill(defaultConfig(), itemWeights, itemValues, capacity)
+	return values
+}
+
+// PackItems behaves like Knapsack but returns the packed items themselves
+// rather than their indices. The items are returned in the same order as they
+// appear in `items`, and an empty (non-nil) slice is returned when nothing is
+// packed.
+func PackItems(items []Packable, capacity int64) []Packable {
+	indices := Knapsack(items, capacity)
+
+	// The traceback produces indices from last to first, so walk them
+	// backwards to preserve the original ordering.
+	packed := make([]Packable, 0, len(indices))
+	for i := len(indices) - 1; i >= 0; i-- {
+		packed = append(packed, items[indices[i]])
+	}
+
+	return packed
+}
+
+// KnapsackFunc behaves like Knapsack, but pulls the items to pack from `next`
+// until it reports that there are no more. The DP needs every item before it
+// can start, so they are still buffered internally. The returned indices refer
+// to the order in which `next` yielded the items, starting from 0.
+func KnapsackFunc(next func() (Packable, bool), capacity int64) []int64 {
+	var items []Packable
+	for {
+		item, ok := next()
+		if !ok {
+			break
+		}
+		items = append(items, item)
+	}
+
+	return Knapsack(items, capacity)
+}
+
+// config holds the settings that change how knapsack fills the DP tables.
+type config struct {
+	// ctx is checked before each row of the tables is calculated.
+	ctx context.Context
+
+	// checkOverflow makes knapsack return ErrOverflow rather than silently
+	// wrapping around when the sum of a set of values overflows an int64.
+	checkOverflow bool
+
+	// tieBreak decides between packing and leaving an item when both give the
+	// same value.
+	tieBreak tieBreak
+
+	// less decides between combinations of items when tieBreak is tieCustom.
+	less func(a, b Solution) bool
+
+	// sortIndices makes knapsack return the indices in ascending order, rather
+	// than the descending order in which the traceback finds them.
+	sortIndices bool
+
+	// noKeep makes fill skip building the `keep` matrix, returning nil in its
+	// place.
+	noKeep bool
+
+	// onRow, if not nil, is called after each row of the tables has been
+	// calculated.
+	onRow func(done, total int)
 
+	// logger, if not nil, is sent diagnostic messages as knapsack runs.
+	logger Logger
+
+	// observe, if not nil, is called with the final value of each cell of the
+	// `values` table as it's calculated.
+	observe func(i int, c int64, value int64)
+}
+
+// tieBreak is a rule for choosing between two combinations of items with the
+// same value.
+type tieBreak int
+
+const (
+	// tieSkip leaves the item out, keeping the previous items' combination.
+	tieSkip tieBreak = iota
+
+	// tieMinWeight prefers whichever combination weighs the least.
+	tieMinWeight
+
+	// tieMaxWeight prefers whichever combination weighs the most.
+	tieMaxWeight
+
+	// tieFewestItems prefers whichever combination packs the fewest items.
+	tieFewestItems
+
+	// tieCustom prefers whichever combination is reported to be less by the
+	// config's `less` function.
+	tieCustom
+)
+
+// defaultConfig returns the config used by Knapsack.
+func defaultConfig() config {
+	return config{
+		ctx: context.Background(),
+	}
+}
+
+// knapsackWithObserver behaves like Knapsack, but calls `observe` with the
+// row, capacity and value of every cell of the `values` table as it's
+// calculated, so that tests can check the DP cell by cell.
+func knapsackWithObserver(items []Packable, capacity int64, observe func(i int, c int64, value int64)) []int64 {
+	cfg := defaultConfig()
+	cfg.observe = observe
+
+	indices, _, _ := knapsack(cfg, items, capacity)
+	return indices
+}
+
+// knapsack builds the DP tables and performs the traceback. It returns the
+// indices of the items to pack along with the total value of those items, or
+// an error if the tables could not be filled under `cfg`.
+func knapsack(cfg config, items []Packable, capacity int64) ([]int64, int64, error) {
+	// With no items, or no room for any, there's nothing to pack, so don't
+	// bother building tables.
+	if len(items) == 0 || capacity < 0 {
+		return []int64{}, 0, nil
+	}
+
+	// The arguments to the logger have to be built even if they're not used,
+	// so only log when there's a logger to log to.
+	if cfg.logger != nil {
+		cfg.logger.Debugf("knapsack: filling tables of %d x %d cells", len(items)+1, capacity+1)
+	}
+
+	itemWeights, itemValues := snapshot(items)
+	values, keep, err := fill(cfg, itemWeights, itemValues, capacity)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// We've now calculated the maximum value to be gained from a combination of
+	// items. The maximum value will live at `values[len(items)][capacity]`
+	indices := traceback(itemWeights, keep, capacity)
+	if cfg.logger != nil {
+		cfg.logger.Debugf("knapsack: found a maximum value of %d", values[len(items)][capacity])
+		cfg.logger.Debugf("knapsack: traceback found %d of %d items", len(indices), len(items))
+	}
+
+	// The traceback finds the items from last to first, so the indices are in
+	// descending order and we only need to reverse them.
+	if cfg.sortIndices {
+		for i, j := 0, len(indices)-1; i < j; i, j = i+1, j-1 {
+			indices[i], indices[j] = indices[j], indices[i]
+		}
+	}
+
+	return indices, values[len(items)][capacity], nil
+}
+
+// traceback loops through the `keep` matrix built for items with the given
+// weights and returns the indices that point to the specific items to pack
+// into a Knapsack of the given capacity, from last to first.
+func traceback(weights []int64, keep [][]int, capacity int64) []int64 {
+	n := len(weights)
+	c := capacity
+	indices := []int64{}
+
+	for n > 0 {
+		if keep[n][c] == 1 {
+			indices = append(indices, int64(n-1))
+			c -= weights[n-1]
+		}
+		n--
+	}
+
+	return indices
+}
+
+// snapshot returns the weights and values of `items`, calling each item's
+// Weight and Value methods exactly once. Solving with a snapshot means that
+// an item whose weight or value changes mid-solve can't corrupt the tables,
+// and saves repeatedly calling through the interface.
+func snapshot(items []Packable) (weights []int64, values []int64) {
+	weights = make([]int64, len(items))
+	values = make([]int64, len(items))
+	for i, item := range items {
+		weights[i], values[i] = item.Weight(), item.Value()
+	}
+
+	return weights, values
+}
+
+// fill builds the DP tables under `cfg` for items with the given weights and
+// values, as returned by snapshot, and `capacity`. It returns the `values`
+// and `keep` matrices, or an error if the tables could not be filled.
+func fill(cfg config, itemWeights []int64, itemValues []int64, capacity int64) ([][]int64, [][]int, error) {
 	// We store our working solutions in matrices of N+1 x M+1, where N is the number
 	// of items and M is the capacity. We add 1 so we can index from 0.
 	// `values` stores the sum of a set of items' values.
-	values := make([][]int64, len(items)+1)
+	values := make([][]int64, len(itemWeights)+1)
 	for i := range values {
 		values[i] = make([]int64, capacity+1)
 	}
 
 	// `keep` stores a matrix of bits, 1 meaning we want to keep the item in this
 	// combination, 0 means we'll leave it.
-	keep := make([][]int, len(items)+1)
-	for i := range keep {
-		keep[i] = make([]int, capacity+1)
+	var keep [][]int
+	if !cfg.noKeep {
+		keep = make([][]int, len(itemWeights)+1)
+		for i := range keep {
+			keep[i] = make([]int, capacity+1)
+		}
 	}
 
-	// Initially, we'll set all combinations in both `values` and `keep` to 0.
-	for i := int64(0); i < capacity+1; i++ {
-		values[0][i] = 0
-		keep[0][i] = 0
+	// Breaking ties by weight needs to know the weight of the combination in
+	// each cell, so in that case `weights` stores those alongside `values`.
+	var weights [][]int64
+	if cfg.tieBreak == tieMinWeight || cfg.tieBreak == tieMaxWeight {
+		weights = make([][]int64, len(itemWeights)+1)
+		for i := range weights {
+			weights[i] = make([]int64, capacity+1)
+		}
 	}
 
-	for i := 0; i < len(items)+1; i++ {
-		values[i][0] = 0
-		keep[i][0] = 0
+	// Likewise, breaking ties by the number of items needs `counts` of the items
+	// in each cell's combination.
+	var counts [][]int64
+	if cfg.tieBreak == tieFewestItems {
+		counts = make([][]int64, len(itemWeights)+1)
+		for i := range counts {
+			counts[i] = make([]int64, capacity+1)
+		}
 	}
 
-	// Simply put, for every item in `items` we want to know whether it will
+	// Simply put, for every item we want to know whether it will
 	// fit in our sack for every capacity from 0 to `capacity`.
-	// We know that with 0 items or 0 capacity, no outcome is possible, so start
-	// from item 1 and capacity of 1.
-	for i := 1; i <= len(items); i++ {
-		for c := int64(1); c <= capacity; c++ {
+	// We know that with 0 items no outcome is possible, so start from item 1.
+	// An item with no weight fits even when there's no capacity, so we do have
+	// to start from a capacity of 0.
+	for i := 1; i <= len(itemWeights); i++ {
+		if err := cfg.ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		w, v := itemWeights[i-1], itemValues[i-1]
+
+		for c := int64(0); c <= capacity; c++ {
+
+			// Until we know otherwise, the best we can do at this capacity is
+			// whatever the previous items managed on their own.
+			previousValueAtThisCapacity := values[i-1][c]
+			values[i][c] = previousValueAtThisCapacity
+			if weights != nil {
+				weights[i][c] = weights[i-1][c]
+			}
+			if counts != nil {
+				counts[i][c] = counts[i-1][c]
+			}
 
 			// Does the item fit at this capacity?
-			itemFits := (items[i-1].Weight() <= c)
+			itemFits := (w <= c)
 			if !itemFits {
+				if cfg.observe != nil {
+					cfg.observe(i, c, values[i][c])
+				}
 				continue // skip this iteration
 			}
 
 			// Is the value of the item, plus the (previously calculated) value of
 			// any remaining space after the addition of this item, greater than the
 			// value gained from the previous item?
-			maxValueAtThisCapacity := items[i-1].Value() + values[i-1][c-items[i-1].Weight()]
-			previousValueAtThisCapacity := values[i-1][c]
+			remainingValue := values[i-1][c-w]
+			if cfg.checkOverflow && addOverflows(v, remainingValue) {
+				return nil, nil, ErrOverflow
+			}
+			maxValueAtThisCapacity := v + remainingValue
 
 			// If the max value to be gained by using this item at this level of
 			// capacity is greater than the value to be gained from using the previous
 			// item at this capacity, then we want to use this item and keep it.
-			// Otherwise, we'll just use the previous item's combination.
-			if itemFits && (maxValueAtThisCapacity > previousValueAtThisCapacity) {
+			// Otherwise, we'll just use the previous item's combination. In
+			// particular, if the two are worth the same we leave the item out,
+			// unless a different tie break has been asked for.
+			take := maxValueAtThisCapacity > previousValueAtThisCapacity
+			if !take && maxValueAtThisCapacity == previousValueAtThisCapacity && cfg.tieBreak != tieSkip {
+				switch cfg.tieBreak {
+				case tieMinWeight:
+					take = w+weights[i-1][c-w] < weights[i-1][c]
+				case tieMaxWeight:
+					take = w+weights[i-1][c-w] > weights[i-1][c]
+				case tieFewestItems:
+					take = 1+counts[i-1][c-w] < counts[i-1][c]
+				case tieCustom:
+					taken := candidate(itemWeights, itemValues, keep, i, c, true)
+					skipped := candidate(itemWeights, itemValues, keep, i, c, false)
+					take = cfg.less(taken, skipped)
+				}
+			}
+
+			if take {
 				values[i][c] = maxValueAtThisCapacity
-				keep[i][c] = 1
-			} else {
-				values[i][c] = previousValueAtThisCapacity
-				keep[i][c] = 0
+				if keep != nil {
+					keep[i][c] = 1
+				}
+				if weights != nil {
+					weights[i][c] = w + weights[i-1][c-w]
+				}
+				if counts != nil {
+					counts[i][c] = 1 + counts[i-1][c-w]
+				}
+			}
+
+			if cfg.observe != nil {
+				cfg.observe(i, c, values[i][c])
 			}
 		}
+
+		if cfg.onRow != nil {
+			cfg.onRow(i, len(itemWeights))
+		}
 	}
 
-	// We've now calculated the maximum value to be gained from a combination of
-	// items. The maximum value will live at `values[len(items)][capacity]`
-	// We now want to loop through our `keep` array and return the indices that
-	// point to the specific items to pack into our Knapsack.
-	n := len(items)
-	c := capacity
-	var indices []int64
+	return values, keep, nil
+}
 
-	for n > 0 {
-		if keep[n][c] == 1 {
-			indices = append(indices, int64(n-1))
-			c -= items[n-1].Weight()
+// boundCapacity returns the smaller of `capacity` and the total weight of the
+// items that fit within it. Beyond that total every item that can be packed
+// fits, so the extra capacity makes no difference to which items Knapsack
+// packs, but would make the tables needlessly large. If any weight is
+// negative, `capacity` is returned as it is.
+func boundCapacity(items []Packable, capacity int64) int64 {
+	var total int64
+	for _, p := range items {
+		w := p.Weight()
+		if w < 0 {
+			return capacity
 		}
-		n--
+		if w > capacity {
+			continue
+		}
+		if addOverflows(total, w) {
+			return capacity
+		}
+		total += w
 	}
 
-	return indices
+	if total < capacity {
+		return total
+	}
+	return capacity
+}
+
+// addOverflows reports whether adding `a` and `b` overflows an int64.
+func addOverflows(a, b int64) bool {
+	return (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b)
 }
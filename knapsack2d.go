@@ -0,0 +1,95 @@
+package knapsack
+
+import (
+	"math/bits"
+)
+
+// A Packable2D item is one that consumes two independent resources, weight and
+// volume, when placed in a Knapsack.
+type Packable2D interface {
+	Weight() int64
+	Volume() int64
+	Value() int64
+}
+
+// Knapsack2D calculates the maximum value to be gained from an array of items
+// whilst keeping both the total weight of the items less than or equal to
+// `maxWeight` and their total volume less than or equal to `maxVolume`. It
+// returns the indices of the items to pack.
+//
+// The DP table gains a dimension for the volume, so Knapsack2D needs
+// (N+1) x (maxWeight+1) x (maxVolume+1) cells, each holding an int64 value and
+// a keep flag. That is roughly 9 bytes per cell, which grows very quickly: 100
+// items with both capacities at 1000 already needs around 900MB. If the tables
+// would need more memory than the Go runtime can allocate, ErrCapacityTooLarge
+// is returned rather than attempting the allocation.
+func Knapsack2D(items []Packable2D, maxWeight, maxVolume int64) ([]int64, error) {
+	if maxWeight < 0 || maxVolume < 0 {
+		return []int64{}, nil
+	}
+	if memory2D(len(items), maxWeight, maxVolume) > maxAlloc {
+		return nil, ErrCapacityTooLarge
+	}
+
+	rows, cols, depth := len(items)+1, int(maxWeight)+1, int(maxVolume)+1
+
+	// `values[i][w][v]` stores the best value using the first `i` items with a
+	// weight capacity of `w` and a volume capacity of `v`, and `keep` records
+	// whether item `i` is packed in that combination.
+	values := make([][][]int64, rows)
+	keep := make([][][]bool, rows)
+	for i := range values {
+		values[i] = make([][]int64, cols)
+		keep[i] = make([][]bool, cols)
+		for w := range values[i] {
+			values[i][w] = make([]int64, depth)
+			keep[i][w] = make([]bool, depth)
+		}
+	}
+
+	for i := 1; i <= len(items); i++ {
+		itemWeight, itemVolume := items[i-1].Weight(), items[i-1].Volume()
+
+		for w := int64(0); w <= maxWeight; w++ {
+			for v := int64(0); v <= maxVolume; v++ {
+				previous := values[i-1][w][v]
+				values[i][w][v] = previous
+
+				// The item has to fit within both capacities to be considered.
+				if itemWeight > w || itemVolume > v {
+					continue
+				}
+
+				if taken := items[i-1].Value() + values[i-1][w-itemWeight][v-itemVolume]; taken > previous {
+					values[i][w][v] = taken
+					keep[i][w][v] = true
+				}
+			}
+		}
+	}
+
+	w, v := maxWeight, maxVolume
+	indices := []int64{}
+	for n := len(items); n > 0; n-- {
+		if keep[n][w][v] {
+			indices = append(indices, int64(n-1))
+			w -= items[n-1].Weight()
+			v -= items[n-1].Volume()
+		}
+	}
+
+	return indices, nil
+}
+
+// memory2D returns roughly how many bytes Knapsack2D allocates for its tables
+// when packing `itemCount` items within the given capacities. It's calculated
+// as a float64, which can't overflow and is more than precise enough to
+// compare against maxAlloc.
+func memory2D(itemCount int, maxWeight, maxVolume int64) float64 {
+	const sliceHeader = 3 * bits.UintSize / 8
+
+	// Both tables have a slice of `cols` slice headers for each item, each of
+	// which points at `depth` cells, of an int64 value and a bool keep flag.
+	rows, cols, depth := float64(itemCount)+1, float64(maxWeight)+1, float64(maxVolume)+1
+	return 2*rows*sliceHeader + rows*cols*(2*sliceHeader+depth*9)
+}
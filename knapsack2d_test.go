@@ -0,0 +1,77 @@
+package knapsack
+
+import (
+	"math"
+	"testing"
+)
+
+type TestKnapsack2DItem struct {
+	weight int64
+	volume int64
+	value  int64
+}
+
+func (i TestKnapsack2DItem) Weight() int64 {
+	return i.weight
+}
+
+func (i TestKnapsack2DItem) Volume() int64 {
+	return i.volume
+}
+
+func (i TestKnapsack2DItem) Value() int64 {
+	return i.value
+}
+
+func TestKnapsack2D(t *testing.T) {
+	items := []Packable2D{
+		TestKnapsack2DItem{
+			3, 1, 5,
+		},
+		TestKnapsack2DItem{
+			2, 4, 3,
+		},
+		TestKnapsack2DItem{
+			1, 4, 4,
+		},
+	}
+
+	// By weight alone we'd pack the first and third items, but together they
+	// take up too much volume.
+	indices, err := Knapsack2D(items, 5, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var value int64 = 0
+	for _, i := range indices {
+		value += items[i].Value()
+	}
+
+	if value != 5 {
+		t.Errorf("Expected %d, got %d", 5, value)
+	}
+}
+
+func TestKnapsack2DTooLarge(t *testing.T) {
+	items := []Packable2D{
+		TestKnapsack2DItem{
+			3, 1, 5,
+		},
+	}
+
+	for _, tc := range []struct {
+		items                []Packable2D
+		maxWeight, maxVolume int64
+	}{
+		{items, math.MaxInt32, math.MaxInt32},
+		{nil, math.MaxInt64 - 1, 0},
+		// There are few enough cells to count in an int, but far too many bytes
+		// to allocate.
+		{items, 1 << 24, 1 << 24},
+	} {
+		if _, err := Knapsack2D(tc.items, tc.maxWeight, tc.maxVolume); err != ErrCapacityTooLarge {
+			t.Errorf("%d x %d: expected %v, got %v", tc.maxWeight, tc.maxVolume, ErrCapacityTooLarge, err)
+		}
+	}
+}
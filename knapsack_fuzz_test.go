@@ -0,0 +1,100 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+// FuzzKnapsack checks that whatever the items and capacity, Knapsack returns
+// a valid packing, worth as much as it claims, and the same one every time,
+// and that the solvers that should match it do.
+// Each pair of bytes in `data` makes an item, with a weight from 0 to 31 and
+// a value from -128 to 127.
+func FuzzKnapsack(f *testing.F) {
+	f.Add([]byte{3, 5, 2, 3, 1, 4}, int16(5))
+	f.Add([]byte{0, 1, 0, 0, 0, 255}, int16(0))
+	f.Add([]byte{10, 200, 31, 127}, int16(-1))
+	f.Add([]byte{}, int16(100))
+
+	f.Fuzz(func(t *testing.T, data []byte, capacity int16) {
+		if len(data) > 64 {
+			data = data[:64]
+		}
+		items := make([]Packable, len(data)/2)
+		for i := range items {
+			items[i] = TestKnapsackItem{
+				int64(data[2*i] % 32), int64(int8(data[2*i+1])),
+			}
+		}
+
+		indices, value := KnapsackWithValue(items, int64(capacity))
+
+		seen := make(map[int64]bool)
+		var weight, total int64
+		for _, i := range indices {
+			if i < 0 || i >= int64(len(items)) {
+				t.Fatalf("Index %d out of range for %d items", i, len(items))
+			}
+			if seen[i] {
+				t.Fatalf("Index %d packed more than once in %v", i, indices)
+			}
+			seen[i] = true
+			weight += items[i].Weight()
+			total += items[i].Value()
+		}
+
+		// Nothing is packed into a negative capacity, which leaves a weight of
+		// 0 that's still greater than the capacity.
+		if len(indices) > 0 && weight > int64(capacity) {
+			t.Errorf("Packed a weight of %d within a capacity of %d", weight, capacity)
+		}
+		if total != value {
+			t.Errorf("Expected the packed items to be worth %d, got %d", value, total)
+		}
+		if v := MaxValue(items, int64(capacity)); v != value {
+			t.Errorf("Expected a value of %d, got %d", value, v)
+		}
+		if count := CountOptimal(items, int64(capacity)); (capacity < 0) != (count == 0) {
+			t.Errorf("Expected at least one optimal set within %d unless it's negative, got %d", capacity, count)
+		}
+
+		for name, solve := range map[string]func() []int64{
+			"KnapsackCompact": func() []int64 { return KnapsackCompact(items, int64(capacity)) },
+			"KnapsackNoKeep":  func() []int64 { return KnapsackNoKeep(items, int64(capacity)) },
+			"KnapsackParallel": func() []int64 {
+				return KnapsackParallel(items, int64(capacity), 3)
+			},
+			"KnapsackExclusive": func() []int64 {
+				indices, _ := KnapsackExclusive(items, nil, int64(capacity))
+				return indices
+			},
+			"KnapsackMaxCount": func() []int64 {
+				return KnapsackMaxCount(items, int64(capacity), len(items))
+			},
+			"Solver": func() []int64 {
+				return NewSolver(items, int64(capacity)).Indices(int64(capacity))
+			},
+		} {
+			if v, _ := ValueOf(items, solve()); v != value {
+				t.Errorf("%s: expected a value of %d, got %d", name, value, v)
+			}
+		}
+
+		table, sweep := KnapsackTable(items, int64(capacity)), KnapsackSweep(items, int64(capacity))
+		if capacity < 0 {
+			if len(table) != 0 || len(sweep) != 0 {
+				t.Errorf("Expected no cells within %d, got %v and %v", capacity, table, sweep)
+			}
+		} else if table[len(items)][capacity] != value || sweep[capacity] != value {
+			t.Errorf("Expected a value of %d in the table and sweep", value)
+		}
+
+		if counts, err := UnboundedKnapsack(items, int64(capacity)); capacity < 0 && err == nil && len(counts) != 0 {
+			t.Errorf("Expected nothing packed within %d, got %v", capacity, counts)
+		}
+
+		if again := Knapsack(items, int64(capacity)); !reflect.DeepEqual(again, indices) {
+			t.Errorf("Expected the same result again, %v, got %v", indices, again)
+		}
+	})
+}
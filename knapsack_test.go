@@ -1,6 +1,11 @@
 package knapsack
 
 import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -41,6 +46,27 @@ func TestKnapsackEasy(t *testing.T) {
 	}
 }
 
+func TestKnapsackItemTooHeavy(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 10,
+		},
+		TestKnapsackItem{
+			5, 1,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+	}
+
+	// The second item never fits, which mustn't make us forget the value of
+	// the first.
+	indices := Knapsack(items, 1)
+	if len(indices) != 1 || indices[0] != 0 {
+		t.Errorf("Expected %v, got %v", []int64{0}, indices)
+	}
+}
+
 func TestZeroCapacityKnapsack(t *testing.T) {
 	items := []Packable{
 		TestKnapsackItem{
@@ -78,3 +104,727 @@ func TestNoItemsForKnapsack(t *testing.T) {
 		t.Errorf("Expected %d, got %d", 0, value)
 	}
 }
+
+func TestKnapsackWithValue(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	for _, capacity := range []int64{0, 1, 3, 5, 10} {
+		expected := Knapsack(items, capacity)
+		indices, value := KnapsackWithValue(items, capacity)
+
+		if len(indices) != len(expected) {
+			t.Errorf("Expected %v, got %v", expected, indices)
+		}
+
+		var sum int64 = 0
+		for _, i := range indices {
+			sum += items[i].Value()
+		}
+		if value != sum {
+			t.Errorf("Expected %d, got %d", sum, value)
+		}
+	}
+
+	indices, value := KnapsackWithValue([]Packable{}, 5)
+	if len(indices) != 0 || value != 0 {
+		t.Errorf("Expected no items and a value of 0, got %v and %d", indices, value)
+	}
+}
+
+func TestPackItems(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	packed := PackItems(items, 4)
+	expected := []Packable{items[0], items[2]}
+	if len(packed) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, packed)
+	}
+	for i := range expected {
+		if packed[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, packed)
+		}
+	}
+
+	packed = PackItems(items, 0)
+	if packed == nil || len(packed) != 0 {
+		t.Errorf("Expected an empty slice, got %v", packed)
+	}
+}
+
+func TestKnapsackContext(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	indices, err := KnapsackContext(context.Background(), items, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := Knapsack(items, 5)
+	if len(indices) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := KnapsackContext(ctx, items, 5); err != context.Canceled {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestKnapsackProgress(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	var done []int
+	indices := KnapsackProgress(items, 5, func(d, total int) {
+		if total != len(items) {
+			t.Errorf("Expected a total of %d, got %d", len(items), total)
+		}
+		done = append(done, d)
+	})
+	if len(done) != 3 || done[0] != 1 || done[1] != 2 || done[2] != 3 {
+		t.Errorf("Expected progress [1 2 3], got %v", done)
+	}
+
+	expected := Knapsack(items, 5)
+	if len(indices) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+
+	// A nil callback is allowed.
+	if indices := KnapsackProgress(items, 5, nil); len(indices) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+}
+
+// countingItem is a Packable that counts how often its methods are called.
+type countingItem struct {
+	weight, value int64
+	calls         *int
+}
+
+func (i countingItem) Weight() int64 {
+	*i.calls++
+	return i.weight
+}
+
+func (i countingItem) Value() int64 {
+	*i.calls++
+	return i.value
+}
+
+func TestKnapsackReadsItemsOnce(t *testing.T) {
+	var calls int
+	items := []Packable{
+		countingItem{3, 5, &calls},
+		countingItem{2, 3, &calls},
+		countingItem{1, 4, &calls},
+	}
+
+	indices := Knapsack(items, 5)
+	if len(indices) != 2 || indices[0] != 2 || indices[1] != 0 {
+		t.Errorf("Expected [2 0], got %v", indices)
+	}
+
+	// Each item's weight and value should be read once, however large the
+	// capacity.
+	if calls != 2*len(items) {
+		t.Errorf("Expected %d calls, got %d", 2*len(items), calls)
+	}
+}
+
+func TestKnapsackProgressChan(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	progress, result := KnapsackProgressChan(items, 5)
+
+	var done []int
+	for d := range progress {
+		done = append(done, d)
+	}
+	if !reflect.DeepEqual(done, []int{1, 2, 3}) {
+		t.Errorf("Expected progress [1 2 3], got %v", done)
+	}
+
+	expected := Knapsack(items, 5)
+	if indices := <-result; !reflect.DeepEqual(indices, expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+	if _, ok := <-result; ok {
+		t.Errorf("Expected the result channel to be closed")
+	}
+}
+
+func TestKnapsackChecked(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	indices, err := KnapsackChecked(items, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := Knapsack(items, 5)
+	if len(indices) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+}
+
+func TestKnapsackCheckedOverflow(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, math.MaxInt64 - 1,
+		},
+		TestKnapsackItem{
+			1, 2,
+		},
+	}
+
+	if _, err := KnapsackChecked(items, 2); err != ErrOverflow {
+		t.Errorf("Expected %v, got %v", ErrOverflow, err)
+	}
+}
+
+func TestKnapsackSorted(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 2,
+		},
+	}
+
+	indices := KnapsackSorted(items, 6)
+	expected := []int64{0, 1, 2}
+	if len(indices) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, indices)
+	}
+	for i := range expected {
+		if indices[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, indices)
+		}
+	}
+}
+
+func TestKnapsackByValue(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 2,
+		},
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 5,
+		},
+		TestKnapsackItem{
+			9, 1,
+		},
+	}
+
+	// The items of equal value stay in index order.
+	indices := KnapsackByValue(items, 7)
+	expected := []int64{1, 3, 2, 0}
+	if !reflect.DeepEqual(indices, expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+}
+
+func TestEmptyItemsForKnapsack(t *testing.T) {
+	for _, items := range [][]Packable{nil, {}} {
+		indices := Knapsack(items, 10)
+		if indices == nil || len(indices) != 0 {
+			t.Errorf("Expected an empty slice, got %#v", indices)
+		}
+	}
+}
+
+func TestKnapsackCheckedInvalidInput(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			-2, 3,
+		},
+	}
+
+	if _, err := KnapsackChecked(items[:1], -1); err != ErrNegativeCapacity {
+		t.Errorf("Expected %v, got %v", ErrNegativeCapacity, err)
+	}
+
+	if _, err := KnapsackChecked(items, 5); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected %v, got %v", ErrNegativeWeight, err)
+	}
+}
+
+func TestKnapsackFunc(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	n := 0
+	next := func() (Packable, bool) {
+		if n == len(items) {
+			return nil, false
+		}
+		n++
+		return items[n-1], true
+	}
+
+	indices := KnapsackFunc(next, 4)
+	expected := Knapsack(items, 4)
+	if len(indices) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, indices)
+	}
+	for i := range expected {
+		if indices[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, indices)
+		}
+	}
+}
+
+func TestKnapsackMinWeight(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			3, 6,
+		},
+	}
+
+	// The first two items are worth as much as the third, but weigh more.
+	// Knapsack keeps the earlier combination when values tie.
+	indices := Knapsack(items, 4)
+	if len(indices) != 2 {
+		t.Fatalf("Expected the first two items, got %v", indices)
+	}
+
+	indices = KnapsackMinWeight(items, 4)
+	if len(indices) != 1 || indices[0] != 2 {
+		t.Errorf("Expected %v, got %v", []int64{2}, indices)
+	}
+}
+
+func TestKnapsackFillBias(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 6,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+	}
+
+	// The last two items are worth as much as the first, but weigh more and
+	// so fill the sack.
+	indices := KnapsackMinWeight(items, 4)
+	if len(indices) != 1 || indices[0] != 0 {
+		t.Errorf("Expected %v, got %v", []int64{0}, indices)
+	}
+
+	indices = KnapsackFillBias(items, 4)
+	if len(indices) != 2 || indices[0] != 2 || indices[1] != 1 {
+		t.Errorf("Expected %v, got %v", []int64{2, 1}, indices)
+	}
+}
+
+func TestKnapsackFewestItems(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 2,
+		},
+		TestKnapsackItem{
+			1, 2,
+		},
+		TestKnapsackItem{
+			1, 2,
+		},
+		TestKnapsackItem{
+			3, 6,
+		},
+	}
+
+	// The first three items weigh and are worth exactly as much as the last
+	// one together, so Knapsack keeps them rather than pack the last.
+	indices := Knapsack(items, 3)
+	if len(indices) != 3 || indices[0] != 2 || indices[1] != 1 || indices[2] != 0 {
+		t.Errorf("Expected %v, got %v", []int64{2, 1, 0}, indices)
+	}
+
+	indices = KnapsackFewestItems(items, 3)
+	if len(indices) != 1 || indices[0] != 3 {
+		t.Errorf("Expected %v, got %v", []int64{3}, indices)
+	}
+}
+
+func TestKnapsackNegativeValueNeverPacked(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, -5,
+		},
+		TestKnapsackItem{
+			0, -1,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			1, -3,
+		},
+	}
+
+	// A row's values never decrease with capacity, so an item with a negative
+	// value is always worth less than leaving it out, even when there's spare
+	// capacity or it weighs nothing, and is never packed whatever the tie
+	// break.
+	for capacity := int64(0); capacity <= 6; capacity++ {
+		for name, indices := range map[string][]int64{
+			"Knapsack":          Knapsack(items, capacity),
+			"KnapsackMinWeight": KnapsackMinWeight(items, capacity),
+			"KnapsackFillBias":  KnapsackFillBias(items, capacity),
+		} {
+			for _, i := range indices {
+				if items[i].Value() < 0 {
+					t.Errorf("%s: capacity %d: packed item %d with value %d", name, capacity, i, items[i].Value())
+				}
+			}
+		}
+	}
+}
+
+func TestKnapsackObserver(t *testing.T) {
+	items := RandomItems(10, 8, 20, 1)
+	const capacity = 30
+
+	// Every cell should be observed exactly once, and the values in
+	// each row should never decrease with capacity, nor fall below the row
+	// before.
+	table := KnapsackTable(items, capacity)
+	seen := make([][]bool, len(items)+1)
+	for i := range seen {
+		seen[i] = make([]bool, capacity+1)
+	}
+
+	lastRow, lastValue := 0, int64(0)
+	indices := knapsackWithObserver(items, capacity, func(i int, c int64, value int64) {
+		if seen[i][c] {
+			t.Errorf("Cell (%d, %d) observed more than once", i, c)
+		}
+		seen[i][c] = true
+
+		if value != table[i][c] {
+			t.Errorf("Cell (%d, %d): expected %d, got %d", i, c, table[i][c], value)
+		}
+		if i == lastRow && c > 0 && value < lastValue {
+			t.Errorf("Cell (%d, %d): value %d is less than %d at the previous capacity", i, c, value, lastValue)
+		}
+		if value < table[i-1][c] {
+			t.Errorf("Cell (%d, %d): value %d is less than %d in the previous row", i, c, value, table[i-1][c])
+		}
+		lastRow, lastValue = i, value
+	})
+
+	for i := 1; i <= len(items); i++ {
+		for c := int64(0); c <= capacity; c++ {
+			if !seen[i][c] {
+				t.Errorf("Cell (%d, %d) was never observed", i, c)
+			}
+		}
+	}
+
+	expected := Knapsack(items, capacity)
+	if !reflect.DeepEqual(indices, expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+}
+
+func TestKnapsackIdenticalItemsDeterministic(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			3, 4,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for capacity := int64(0); capacity <= 11; capacity++ {
+		expected := Knapsack(items, capacity)
+		_, value := KnapsackWithValue(items, capacity)
+
+		// Swapping identical items leaves the problem exactly as it was, so
+		// the same indices should be returned, and they should be the first
+		// copies of each item.
+		for p := range items {
+			for q := p + 1; q < len(items); q++ {
+				if items[p] != items[q] {
+					continue
+				}
+
+				swapped := append([]Packable{}, items...)
+				swapped[p], swapped[q] = swapped[q], swapped[p]
+				if indices := Knapsack(swapped, capacity); !reflect.DeepEqual(indices, expected) {
+					t.Errorf("Capacity %d, swapping %d and %d: expected %v, got %v", capacity, p, q, expected, indices)
+				}
+
+				packed := make(map[int64]bool)
+				for _, i := range expected {
+					packed[i] = true
+				}
+				if packed[int64(q)] && !packed[int64(p)] {
+					t.Errorf("Capacity %d: packed item %d but not the identical item %d before it", capacity, q, p)
+				}
+			}
+		}
+
+		// Any other reordering may pick different items, but never a
+		// different value.
+		for n := 0; n < 10; n++ {
+			shuffled := append([]Packable{}, items...)
+			r.Shuffle(len(shuffled), func(a, b int) {
+				shuffled[a], shuffled[b] = shuffled[b], shuffled[a]
+			})
+			if _, v := KnapsackWithValue(shuffled, capacity); v != value {
+				t.Errorf("Capacity %d: expected %d, got %d for %v", capacity, value, v, shuffled)
+			}
+		}
+	}
+}
+
+func TestKnapsackTable(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	expected := [][]int64{
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 5, 5, 5},
+		{0, 0, 3, 5, 5, 8},
+		{0, 4, 4, 7, 9, 9},
+	}
+
+	table := KnapsackTable(items, 5)
+	if len(table) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, table)
+	}
+	for i := range expected {
+		for c := range expected[i] {
+			if table[i][c] != expected[i][c] {
+				t.Errorf("Expected %v, got %v", expected, table)
+			}
+		}
+	}
+
+	_, value := KnapsackWithValue(items, 5)
+	if table[len(items)][5] != value {
+		t.Errorf("Expected %d, got %d", value, table[len(items)][5])
+	}
+}
+
+func TestKnapsackSplit(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			4, 2,
+		},
+	}
+
+	packed, skipped := KnapsackSplit(items, 4)
+	expectedPacked, expectedSkipped := []int64{0, 2}, []int64{1, 3}
+
+	if len(packed) != len(expectedPacked) || len(skipped) != len(expectedSkipped) {
+		t.Fatalf("Expected %v and %v, got %v and %v", expectedPacked, expectedSkipped, packed, skipped)
+	}
+	for i := range expectedPacked {
+		if packed[i] != expectedPacked[i] {
+			t.Errorf("Expected %v, got %v", expectedPacked, packed)
+		}
+	}
+	for i := range expectedSkipped {
+		if skipped[i] != expectedSkipped[i] {
+			t.Errorf("Expected %v, got %v", expectedSkipped, skipped)
+		}
+	}
+
+	packed, skipped = KnapsackSplit(items, 0)
+	if len(packed) != 0 || len(skipped) != len(items) {
+		t.Errorf("Expected every item to be skipped, got %v and %v", packed, skipped)
+	}
+}
+
+func TestKnapsackTieBreak(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 0,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			4, 6,
+		},
+	}
+
+	// All of {0, 2}, {0, 3}, {2, 3} and {4} are worth 6 within a capacity of
+	// 4, and any of them could also include item 1 within a capacity of 5.
+	// Leaving items out on a tie means the earliest pair is always chosen,
+	// and item 1 never is.
+	for _, capacity := range []int64{4, 5} {
+		indices := Knapsack(items, capacity)
+		expected := []int64{2, 0}
+		if len(indices) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, indices)
+		}
+		for i := range expected {
+			if indices[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, indices)
+			}
+		}
+	}
+}
+
+func TestZeroWeightItemsAlwaysPacked(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			0, 2,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			0, 1,
+		},
+	}
+
+	for _, capacity := range []int64{0, 1, 2, 5, 10} {
+		packed := make(map[int64]bool)
+		for _, i := range Knapsack(items, capacity) {
+			packed[i] = true
+		}
+
+		if !packed[1] || !packed[3] {
+			t.Errorf("Expected items 1 and 3 to be packed at a capacity of %d, got %v", capacity, packed)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package knapsack
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SolveLabeled packs a set of labelled items into a Knapsack of the given
+// capacity, returning the keys of the packed items rather than their indices.
+//
+// Go randomises the iteration order of maps, and when several sets of items
+// are equally valuable the one that Knapsack picks depends on the order of
+// the items. To keep the result deterministic, the keys are sorted by their
+// default string formatting (as produced by fmt.Sprint) before solving, and
+// the packed keys are returned in that order. Keys that format identically
+// may still be ordered arbitrarily.
+func SolveLabeled[K comparable](items map[K]Packable, capacity int64) []K {
+	keys := make([]K, 0, len(items))
+	labels := make(map[K]string, len(items))
+	for k := range items {
+		keys = append(keys, k)
+		labels[k] = fmt.Sprint(k)
+	}
+
+	sort.SliceStable(keys, func(a, b int) bool {
+		return labels[keys[a]] < labels[keys[b]]
+	})
+
+	ordered := make([]Packable, len(keys))
+	for i, k := range keys {
+		ordered[i] = items[k]
+	}
+
+	packed := []K{}
+	for _, i := range KnapsackSorted(ordered, capacity) {
+		packed = append(packed, keys[i])
+	}
+
+	return packed
+}
@@ -0,0 +1,49 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestSolveLabeled(t *testing.T) {
+	items := map[string]Packable{
+		"tent": TestKnapsackItem{
+			3, 5,
+		},
+		"stove": TestKnapsackItem{
+			2, 3,
+		},
+		"torch": TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	packed := SolveLabeled(items, 4)
+	expected := []string{"tent", "torch"}
+	if len(packed) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, packed)
+	}
+	for i := range expected {
+		if packed[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, packed)
+		}
+	}
+}
+
+func TestSolveLabeledDeterministic(t *testing.T) {
+	// Every pair of these items is equally valuable, so the result depends
+	// entirely on the order in which they're solved.
+	items := map[int]Packable{}
+	for k := 0; k < 10; k++ {
+		items[k] = TestKnapsackItem{
+			1, 1,
+		}
+	}
+
+	expected := SolveLabeled(items, 2)
+	for n := 0; n < 20; n++ {
+		packed := SolveLabeled(items, 2)
+		if len(packed) != len(expected) || packed[0] != expected[0] || packed[1] != expected[1] {
+			t.Errorf("Expected %v, got %v", expected, packed)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package knapsack
+
+// A Logger receives diagnostic messages about a solve, such as the size of the
+// DP tables and the value found, to help explain the solver's behaviour on
+// real data. A *log.Logger can be adapted with a small wrapper, and
+// structured loggers typically provide a similar method.
+type Logger interface {
+	Debugf(format string, args ...any)
+}
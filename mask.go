@@ -0,0 +1,19 @@
+package knapsack
+
+import (
+	"math/big"
+)
+
+// KnapsackMask behaves like Knapsack, but returns the packed items as a
+// bitmask rather than a slice of indices: bit `i` of the mask (counting from
+// the least significant bit, so bit 0 is item 0) is set if and only if item
+// `i` is packed. A big.Int is used so that there's no limit on the number of
+// items. If nothing is packed, the mask is zero.
+func KnapsackMask(items []Packable, capacity int64) *big.Int {
+	mask := new(big.Int)
+	for _, i := range Knapsack(items, capacity) {
+		mask.SetBit(mask, int(i), 1)
+	}
+
+	return mask
+}
@@ -0,0 +1,47 @@
+package knapsack
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestKnapsackMask(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	// Items 0 and 2 are packed, so bits 0 and 2 are set.
+	mask := KnapsackMask(items, 4)
+	if mask.Int64() != 5 {
+		t.Errorf("Expected %b, got %b", 5, mask)
+	}
+
+	if mask := KnapsackMask(items, 0); mask.Sign() != 0 {
+		t.Errorf("Expected an empty mask, got %b", mask)
+	}
+}
+
+func TestKnapsackMaskManyItems(t *testing.T) {
+	items := make([]Packable, 100)
+	for i := range items {
+		items[i] = TestKnapsackItem{
+			1, 1,
+		}
+	}
+	items[99] = TestKnapsackItem{
+		1, 2,
+	}
+
+	expected := new(big.Int).Lsh(big.NewInt(1), 99)
+	if mask := KnapsackMask(items, 1); mask.Cmp(expected) != 0 {
+		t.Errorf("Expected %b, got %b", expected, mask)
+	}
+}
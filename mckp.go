@@ -0,0 +1,66 @@
+package knapsack
+
+import (
+	"math"
+)
+
+// MultipleChoiceKnapsack solves the multiple-choice knapsack problem, in which
+// exactly one item must be packed from each of `groups`, such as when picking
+// a tier for each of several features. It returns a pair of the group index
+// and the index of the chosen item within that group for every group, in
+// order, maximising the total value within capacity.
+//
+// Each group becomes a row of the DP table, with `values[g][c]` storing the
+// best value of one item from each of the first `g` groups within a capacity
+// of `c`. This takes O(N x M) time, where N is the total number of items, and
+// O(G x M) memory for G groups. ErrInfeasible is returned if no combination of
+// one item per group fits within capacity.
+func MultipleChoiceKnapsack(groups [][]Packable, capacity int64) ([][2]int64, error) {
+	if capacity < 0 {
+		return nil, ErrInfeasible
+	}
+
+	// A cell that can't be reached with one item from each group holds
+	// math.MinInt64, and `chosen[g][c]` stores the index of the item from group
+	// `g` used to reach each cell.
+	values := make([][]int64, len(groups)+1)
+	chosen := make([][]int64, len(groups)+1)
+	for g := range values {
+		values[g] = make([]int64, capacity+1)
+		chosen[g] = make([]int64, capacity+1)
+		if g > 0 {
+			for c := range values[g] {
+				values[g][c] = math.MinInt64
+			}
+		}
+	}
+
+	for g := 1; g <= len(groups); g++ {
+		for c := int64(0); c <= capacity; c++ {
+			for i, item := range groups[g-1] {
+				w := item.Weight()
+				if w > c || values[g-1][c-w] == math.MinInt64 {
+					continue
+				}
+				if v := item.Value() + values[g-1][c-w]; v > values[g][c] {
+					values[g][c] = v
+					chosen[g][c] = int64(i)
+				}
+			}
+		}
+	}
+
+	if values[len(groups)][capacity] == math.MinInt64 {
+		return nil, ErrInfeasible
+	}
+
+	c := capacity
+	picks := make([][2]int64, len(groups))
+	for g := len(groups); g > 0; g-- {
+		i := chosen[g][c]
+		picks[g-1] = [2]int64{int64(g - 1), i}
+		c -= groups[g-1][i].Weight()
+	}
+
+	return picks, nil
+}
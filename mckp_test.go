@@ -0,0 +1,54 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestMultipleChoiceKnapsack(t *testing.T) {
+	groups := [][]Packable{
+		{
+			TestKnapsackItem{1, 1},
+			TestKnapsackItem{3, 5},
+			TestKnapsackItem{5, 8},
+		},
+		{
+			TestKnapsackItem{2, 2},
+			TestKnapsackItem{4, 7},
+		},
+	}
+
+	picks, err := MultipleChoiceKnapsack(groups, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The second tier of both groups, worth 12.
+	expected := [][2]int64{{0, 1}, {1, 1}}
+	if len(picks) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, picks)
+	}
+	for i := range expected {
+		if picks[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, picks)
+		}
+	}
+}
+
+func TestMultipleChoiceKnapsackInfeasible(t *testing.T) {
+	groups := [][]Packable{
+		{
+			TestKnapsackItem{3, 5},
+		},
+		{
+			TestKnapsackItem{2, 2},
+		},
+	}
+
+	if _, err := MultipleChoiceKnapsack(groups, 4); err != ErrInfeasible {
+		t.Errorf("Expected %v, got %v", ErrInfeasible, err)
+	}
+
+	if _, err := MultipleChoiceKnapsack([][]Packable{{}}, 4); err != ErrInfeasible {
+		t.Errorf("Expected %v, got %v", ErrInfeasible, err)
+	}
+}
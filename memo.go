@@ -0,0 +1,62 @@
+package knapsack
+
+// KnapsackMemo solves the 0/1 Knapsack problem with a top-down, memoized
+// recursion rather than filling in a table, returning the indices of the
+// items to pack in ascending order.
+//
+// Knapsack calculates a value for every capacity from 0 to `capacity`, even
+// though most of them can never be reached by subtracting item weights from
+// the capacity. The recursion only visits the (item, remaining capacity)
+// states that can actually be reached, caching each in a map, which can be
+// dramatically faster and smaller when the weights are large and the number
+// of distinct remaining capacities is small. When most states are reachable
+// the overhead of the map makes it slower than Knapsack.
+func KnapsackMemo(items []Packable, capacity int64) []int64 {
+	m := memo{
+		items: items,
+		cache: make(map[[2]int64]int64),
+	}
+
+	// Walk forwards through the items. If the best value from here on changes
+	// when item `i` isn't available, then the best solution must pack it.
+	indices := []int64{}
+	c := capacity
+	for i := 0; i < len(items); i++ {
+		if m.best(i, c) != m.best(i+1, c) {
+			indices = append(indices, int64(i))
+			c -= items[i].Weight()
+		}
+	}
+
+	return indices
+}
+
+// memo caches the best value that can be gained from the items from index
+// `i` onwards with `c` capacity remaining, keyed by `[2]int64{i, c}`.
+type memo struct {
+	items []Packable
+	cache map[[2]int64]int64
+}
+
+// best returns the best value that can be gained from the items from index `i`
+// onwards with a capacity of `c`.
+func (m memo) best(i int, c int64) int64 {
+	if i == len(m.items) {
+		return 0
+	}
+
+	key := [2]int64{int64(i), c}
+	if value, ok := m.cache[key]; ok {
+		return value
+	}
+
+	value := m.best(i+1, c)
+	if w := m.items[i].Weight(); w <= c {
+		if taken := m.items[i].Value() + m.best(i+1, c-w); taken > value {
+			value = taken
+		}
+	}
+
+	m.cache[key] = value
+	return value
+}
@@ -0,0 +1,65 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackMemo(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			12, 40,
+		},
+		TestKnapsackItem{
+			7, 27,
+		},
+		TestKnapsackItem{
+			11, 35,
+		},
+		TestKnapsackItem{
+			8, 29,
+		},
+		TestKnapsackItem{
+			9, 31,
+		},
+	}
+
+	for _, capacity := range []int64{0, 5, 10, 20, 26, 40, 100} {
+		_, expected := KnapsackWithValue(items, capacity)
+
+		var value, weight int64 = 0, 0
+		for _, i := range KnapsackMemo(items, capacity) {
+			value += items[i].Value()
+			weight += items[i].Weight()
+		}
+
+		if value != expected {
+			t.Errorf("Expected %d, got %d", expected, value)
+		}
+		if weight > capacity {
+			t.Errorf("Expected a weight of at most %d, got %d", capacity, weight)
+		}
+	}
+}
+
+func TestKnapsackMemoLargeWeights(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3000000000, 5,
+		},
+		TestKnapsackItem{
+			2000000000, 3,
+		},
+		TestKnapsackItem{
+			1000000000, 4,
+		},
+	}
+
+	var value int64 = 0
+	for _, i := range KnapsackMemo(items, 4000000000) {
+		value += items[i].Value()
+	}
+
+	if value != 9 {
+		t.Errorf("Expected %d, got %d", 9, value)
+	}
+}
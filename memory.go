@@ -0,0 +1,27 @@
+package knapsack
+
+import (
+	"math"
+	"math/bits"
+)
+
+// EstimateMemory returns roughly how many bytes Knapsack will allocate for
+// the `values` and `keep` matrices when packing `itemCount` items into a
+// Knapsack of the given capacity, so that callers can turn away inputs that
+// would need more memory than they can afford before solving. Both matrices
+// have (N+1) x (M+1) cells, with an int64 per cell of `values` and an int per
+// cell of `keep`. If the estimate doesn't fit in an int64, math.MaxInt64 is
+// returned.
+func EstimateMemory(itemCount int, capacity int64) int64 {
+	if itemCount < 0 || capacity < 0 {
+		return 0
+	}
+
+	const cellSize = 8 + bits.UintSize/8
+
+	rows, cols := int64(itemCount)+1, capacity+1
+	if cols <= 0 || cols > math.MaxInt64/rows/cellSize {
+		return math.MaxInt64
+	}
+	return rows * cols * cellSize
+}
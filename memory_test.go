@@ -0,0 +1,27 @@
+package knapsack
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+func TestEstimateMemory(t *testing.T) {
+	cellSize := int64(8 + bits.UintSize/8)
+
+	for _, tc := range []struct {
+		itemCount int
+		capacity  int64
+		expected  int64
+	}{
+		{0, 0, cellSize},
+		{3, 5, 4 * 6 * cellSize},
+		{999, 9999, 1000 * 10000 * cellSize},
+		{10, math.MaxInt64, math.MaxInt64},
+		{math.MaxInt32, math.MaxInt32, math.MaxInt64},
+	} {
+		if estimate := EstimateMemory(tc.itemCount, tc.capacity); estimate != tc.expected {
+			t.Errorf("%d items, capacity %d: expected %d, got %d", tc.itemCount, tc.capacity, tc.expected, estimate)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package knapsack
+
+import (
+	"sort"
+)
+
+// KnapsackMITM solves the 0/1 Knapsack problem exactly using a
+// meet-in-the-middle search, returning the indices of the items to pack in
+// ascending order. It is intended for a small number of items (up to around
+// 40) with a capacity far too large for the DP used by Knapsack.
+//
+// The items are split into two halves, and every subset of each half that
+// fits within capacity is enumerated. For every subset of the first half, the
+// most valuable subset of the second half that fits in the remaining capacity
+// is then found with a binary search. This takes O(2^(N/2) * N) time and
+// O(2^(N/2)) memory, independent of capacity.
+//
+// Items that could never be part of an optimal solution are dropped before
+// splitting. As a subset is stored as a 64 bit mask over half of the
+// remaining items, at most 128 can be split. Far too many subsets of that
+// many items exist to enumerate anyway, so if more remain, KnapsackMITM
+// falls back to KnapsackBranchBound.
+func KnapsackMITM(items []Packable, capacity int64) []int64 {
+	if capacity < 0 {
+		return []int64{}
+	}
+
+	var candidates []int64
+	for i, item := range items {
+		if item.Weight() >= 0 && item.Weight() <= capacity && item.Value() > 0 {
+			candidates = append(candidates, int64(i))
+		}
+	}
+	if len(candidates) > 128 {
+		return KnapsackBranchBound(items, capacity)
+	}
+
+	half := len(candidates) / 2
+	left := subsets(items, candidates[:half], capacity)
+	right := subsets(items, candidates[half:], capacity)
+
+	// Sort the right half by weight and drop any subset that is no more
+	// valuable than a lighter one, so that the values increase with weight and
+	// the heaviest subset that fits is also the most valuable.
+	sort.Slice(right, func(a, b int) bool {
+		if right[a].weight != right[b].weight {
+			return right[a].weight < right[b].weight
+		}
+		return right[a].value > right[b].value
+	})
+	frontier := right[:0]
+	for _, s := range right {
+		if len(frontier) == 0 || s.value > frontier[len(frontier)-1].value {
+			frontier = append(frontier, s)
+		}
+	}
+
+	var best subset
+	var bestRight subset
+	bestValue := int64(-1)
+	for _, l := range left {
+		remaining := capacity - l.weight
+		j := sort.Search(len(frontier), func(j int) bool {
+			return frontier[j].weight > remaining
+		}) - 1
+
+		// The empty subset always fits, so there's always a match.
+		if value := l.value + frontier[j].value; value > bestValue {
+			bestValue = value
+			best, bestRight = l, frontier[j]
+		}
+	}
+
+	indices := []int64{}
+	for k := 0; k < half; k++ {
+		if best.mask&(1<<k) != 0 {
+			indices = append(indices, candidates[k])
+		}
+	}
+	for k := 0; k < len(candidates)-half; k++ {
+		if bestRight.mask&(1<<k) != 0 {
+			indices = append(indices, candidates[half+k])
+		}
+	}
+
+	return indices
+}
+
+// subset is a set of items, with bit k of mask set if the item at position k
+// of the half it was enumerated from is in the set.
+type subset struct {
+	mask   uint64
+	weight int64
+	value  int64
+}
+
+// subsets enumerates each subset of the items at `indices` whose total weight
+// is at most `capacity`.
+func subsets(items []Packable, indices []int64, capacity int64) []subset {
+	all := []subset{{}}
+	for k, i := range indices {
+		w, v := items[i].Weight(), items[i].Value()
+
+		for _, s := range all {
+			if w <= capacity-s.weight {
+				all = append(all, subset{
+					mask:   s.mask | 1<<k,
+					weight: s.weight + w,
+					value:  s.value + v,
+				})
+			}
+		}
+	}
+
+	return all
+}
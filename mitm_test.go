@@ -0,0 +1,91 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackMITM(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			12, 40,
+		},
+		TestKnapsackItem{
+			7, 27,
+		},
+		TestKnapsackItem{
+			11, 35,
+		},
+		TestKnapsackItem{
+			8, 29,
+		},
+		TestKnapsackItem{
+			9, 31,
+		},
+		TestKnapsackItem{
+			3, 8,
+		},
+		TestKnapsackItem{
+			5, 1,
+		},
+	}
+
+	for _, capacity := range []int64{0, 5, 10, 20, 26, 40, 100} {
+		_, expected := KnapsackWithValue(items, capacity)
+
+		var value, weight int64 = 0, 0
+		for _, i := range KnapsackMITM(items, capacity) {
+			value += items[i].Value()
+			weight += items[i].Weight()
+		}
+
+		if value != expected {
+			t.Errorf("Expected %d, got %d", expected, value)
+		}
+		if weight > capacity {
+			t.Errorf("Expected a weight of at most %d, got %d", capacity, weight)
+		}
+	}
+}
+
+func TestKnapsackMITMHugeCapacity(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			4000000000000, 5,
+		},
+		TestKnapsackItem{
+			3000000000000, 3,
+		},
+		TestKnapsackItem{
+			2000000000000, 4,
+		},
+	}
+
+	var value int64 = 0
+	for _, i := range KnapsackMITM(items, 6000000000000) {
+		value += items[i].Value()
+	}
+
+	if value != 9 {
+		t.Errorf("Expected %d, got %d", 9, value)
+	}
+}
+
+func TestKnapsackMITMManyItems(t *testing.T) {
+	// With room for just one item, there are only as many subsets as items.
+	items := make([]Packable, 200)
+	for i := range items {
+		items[i] = TestKnapsackItem{
+			1, int64(1 + i%7),
+		}
+	}
+
+	// Up to 128 items are split in half, and more fall back to branch and
+	// bound.
+	for _, n := range []int{100, 128, 129, 200} {
+		indices := KnapsackMITM(items[:n], 1)
+		value, _ := ValueOf(items, indices)
+		if expected := MaxValue(items[:n], 1); len(indices) != 1 || value != expected {
+			t.Errorf("%d items: expected a single item worth %d, got %v", n, expected, indices)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package knapsack
+
+// MultiKnapsack distributes items across several knapsacks, each with its own
+// capacity, such that every item is packed into at most one of them. It
+// returns the indices of the items packed into each knapsack, in the same
+// order as `capacities`.
+//
+// The multiple knapsack problem is considerably harder than the 0/1 problem,
+// so MultiKnapsack uses a heuristic rather than solving it exactly: the
+// knapsacks are filled one at a time, in the order given, each with the
+// optimal selection from the items not already packed into an earlier one.
+// This runs in O(K * N * M) time, where K is the number of knapsacks, N the
+// number of items and M the largest capacity, and only ever needs the memory
+// of a single call to Knapsack. The result is always a valid packing but is
+// not guaranteed to be optimal.
+func MultiKnapsack(items []Packable, capacities []int64) [][]int64 {
+	sacks := make([][]int64, len(capacities))
+
+	// `remaining` holds the original indices of the items still to be packed.
+	remaining := make([]int64, len(items))
+	for i := range remaining {
+		remaining[i] = int64(i)
+	}
+
+	for s, capacity := range capacities {
+		candidates := make([]Packable, len(remaining))
+		for i, r := range remaining {
+			candidates[i] = items[r]
+		}
+
+		packed := make(map[int64]bool)
+		sacks[s] = []int64{}
+		for _, i := range Knapsack(candidates, capacity) {
+			sacks[s] = append(sacks[s], remaining[i])
+			packed[i] = true
+		}
+
+		var unpacked []int64
+		for i, r := range remaining {
+			if !packed[int64(i)] {
+				unpacked = append(unpacked, r)
+			}
+		}
+		remaining = unpacked
+	}
+
+	return sacks
+}
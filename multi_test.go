@@ -0,0 +1,49 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestMultiKnapsack(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			4, 6,
+		},
+	}
+	capacities := []int64{5, 4}
+
+	sacks := MultiKnapsack(items, capacities)
+	if len(sacks) != len(capacities) {
+		t.Fatalf("Expected %d sacks, got %d", len(capacities), len(sacks))
+	}
+
+	var value int64 = 0
+	seen := make(map[int64]bool)
+	for s, sack := range sacks {
+		var weight int64 = 0
+		for _, i := range sack {
+			if seen[i] {
+				t.Errorf("Item %d was packed more than once", i)
+			}
+			seen[i] = true
+			weight += items[i].Weight()
+			value += items[i].Value()
+		}
+		if weight > capacities[s] {
+			t.Errorf("Expected a weight of at most %d, got %d", capacities[s], weight)
+		}
+	}
+
+	if value != 15 {
+		t.Errorf("Expected %d, got %d", 15, value)
+	}
+}
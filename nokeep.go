@@ -0,0 +1,31 @@
+package knapsack
+
+// KnapsackNoKeep behaves exactly like Knapsack, returning the same indices,
+// but doesn't build the `keep` matrix, roughly halving the memory needed.
+//
+// Knapsack only keeps an item when packing it is worth strictly more than
+// leaving it out, so whether item `i` was kept at capacity `c` can be read
+// straight from the `values` matrix: it was if and only if `values[i][c]`
+// differs from `values[i-1][c]`.
+func KnapsackNoKeep(items []Packable, capacity int64) []int64 {
+	if len(items) == 0 || capacity < 0 {
+		return []int64{}
+	}
+
+	cfg := defaultConfig()
+	cfg.noKeep = true
+
+	weights, itemValues := snapshot(items)
+	values, _, _ := fill(cfg, weights, itemValues, capacity)
+
+	indices := []int64{}
+	c := capacity
+	for n := len(items); n > 0; n-- {
+		if values[n][c] != values[n-1][c] {
+			indices = append(indices, int64(n-1))
+			c -= weights[n-1]
+		}
+	}
+
+	return indices
+}
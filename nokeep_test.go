@@ -0,0 +1,48 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackNoKeep(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			0, 2,
+		},
+	}
+
+	for capacity := int64(0); capacity <= 9; capacity++ {
+		expected := Knapsack(items, capacity)
+		if indices := KnapsackNoKeep(items, capacity); !reflect.DeepEqual(indices, expected) {
+			t.Errorf("Capacity %d: expected %v, got %v", capacity, expected, indices)
+		}
+	}
+
+	if indices := KnapsackNoKeep(nil, 5); indices == nil || len(indices) != 0 {
+		t.Errorf("Expected an empty slice, got %#v", indices)
+	}
+}
+
+func TestKnapsackNoKeepRandom(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		items := RandomItems(30, 20, 10, seed)
+
+		expected := Knapsack(items, 100)
+		if indices := KnapsackNoKeep(items, 100); !reflect.DeepEqual(indices, expected) {
+			t.Errorf("Seed %d: expected %v, got %v", seed, expected, indices)
+		}
+	}
+}
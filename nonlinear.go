@@ -0,0 +1,74 @@
+package knapsack
+
+// KnapsackNonlinear solves a generalisation of the bounded and unbounded
+// Knapsack problems, in which the value of an item needn't grow in proportion
+// to the number of copies packed, such as when returns diminish. Item `i`
+// weighs `weight[i]` per copy, and `valueFunc[i](k)` gives the total value of
+// packing `k` copies of it, which should normally be 0 for 0 copies. It
+// returns a map of item index to the number of copies of that item to pack;
+// items that should not be packed are left out of the map.
+//
+// As the value of each copy can differ, the DP has to consider every possible
+// number of copies of every item at every capacity: `values[i][c]` stores the
+// best value of the first `i` items within a capacity of `c`, and is the best
+// of packing each count `k` of item `i` that fits on top of
+// `values[i-1][c-k*w]`. This takes O(N x M^2 / W) time, for a typical weight
+// of W, rather than the O(N x M) of Knapsack, though `valueFunc[i]` is only
+// called once for each count up to `capacity / weight[i]`.
+//
+// ErrLengthMismatch is returned if `valueFunc` doesn't have one entry per
+// weight. An item that weighs nothing could be packed any number of times,
+// so ErrZeroWeightItem is returned if any weight isn't positive.
+func KnapsackNonlinear(weight []int64, valueFunc []func(count int64) int64, capacity int64) (map[int64]int64, error) {
+	if len(valueFunc) != len(weight) {
+		return nil, ErrLengthMismatch
+	}
+	for _, w := range weight {
+		if w <= 0 {
+			return nil, ErrZeroWeightItem
+		}
+	}
+
+	copies := make(map[int64]int64)
+	if capacity < 0 {
+		return copies, nil
+	}
+
+	values := make([][]int64, len(weight)+1)
+	counts := make([][]int64, len(weight)+1)
+	for i := range values {
+		values[i] = make([]int64, capacity+1)
+		counts[i] = make([]int64, capacity+1)
+	}
+
+	for i := 1; i <= len(weight); i++ {
+		w := weight[i-1]
+
+		// `worth[k]` is the value of packing `k` copies of the item.
+		worth := make([]int64, capacity/w+1)
+		for k := range worth {
+			worth[k] = valueFunc[i-1](int64(k))
+		}
+
+		for c := int64(0); c <= capacity; c++ {
+			values[i][c] = values[i-1][c] + worth[0]
+
+			for k := int64(1); k*w <= c; k++ {
+				if v := values[i-1][c-k*w] + worth[k]; v > values[i][c] {
+					values[i][c] = v
+					counts[i][c] = k
+				}
+			}
+		}
+	}
+
+	c := capacity
+	for n := len(weight); n > 0; n-- {
+		if k := counts[n][c]; k > 0 {
+			copies[int64(n-1)] = k
+			c -= k * weight[n-1]
+		}
+	}
+
+	return copies, nil
+}
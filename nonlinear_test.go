@@ -0,0 +1,93 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackNonlinear(t *testing.T) {
+	linear := func(value int64) func(int64) int64 {
+		return func(k int64) int64 {
+			return k * value
+		}
+	}
+
+	// The first copy of the first item is worth 10, but each one after that
+	// is worth only 1.
+	diminishing := func(k int64) int64 {
+		if k == 0 {
+			return 0
+		}
+		return 10 + (k - 1)
+	}
+
+	copies, err := KnapsackNonlinear(
+		[]int64{1, 1},
+		[]func(int64) int64{diminishing, linear(3)},
+		4,
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[int64]int64{0: 1, 1: 3}
+	if !reflect.DeepEqual(copies, expected) {
+		t.Errorf("Expected %v, got %v", expected, copies)
+	}
+}
+
+func TestKnapsackNonlinearMatchesUnbounded(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			4, 8,
+		},
+	}
+
+	weights := make([]int64, len(items))
+	funcs := make([]func(int64) int64, len(items))
+	for i, item := range items {
+		v := item.Value()
+		weights[i] = item.Weight()
+		funcs[i] = func(k int64) int64 {
+			return k * v
+		}
+	}
+
+	for capacity := int64(0); capacity <= 15; capacity++ {
+		copies, err := KnapsackNonlinear(weights, funcs, capacity)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		unbounded, _ := UnboundedKnapsack(items, capacity)
+
+		var value, expected int64
+		for i, k := range copies {
+			value += k * items[i].Value()
+		}
+		for i, k := range unbounded {
+			expected += k * items[i].Value()
+		}
+		if value != expected {
+			t.Errorf("Capacity %d: expected %d, got %d (%v)", capacity, expected, value, copies)
+		}
+	}
+}
+
+func TestKnapsackNonlinearInvalid(t *testing.T) {
+	one := func(k int64) int64 {
+		return k
+	}
+
+	if _, err := KnapsackNonlinear([]int64{1, 2}, []func(int64) int64{one}, 4); err != ErrLengthMismatch {
+		t.Errorf("Expected %v, got %v", ErrLengthMismatch, err)
+	}
+	if _, err := KnapsackNonlinear([]int64{0}, []func(int64) int64{one}, 4); err != ErrZeroWeightItem {
+		t.Errorf("Expected %v, got %v", ErrZeroWeightItem, err)
+	}
+}
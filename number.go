@@ -0,0 +1,123 @@
+package knapsack
+
+import (
+	"math"
+	"strconv"
+)
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	Integer | Float
+}
+
+// A NumberPackable item is one that can be placed in a Knapsack, with a
+// weight and value of any integer or floating-point type T.
+type NumberPackable[T Number] interface {
+	Weight() T
+	Value() T
+}
+
+// KnapsackNumber packs items whose weights and values are of any integer or
+// floating-point type T into a Knapsack of the given capacity, and returns the
+// indices of the items to pack, in the same order as Knapsack.
+//
+// The DP needs whole units of capacity, so when T is a floating-point type,
+// every weight and value, along with the capacity, is scaled to an integer
+// first, exactly as KnapsackFloat does. A float32 is taken to be the shortest
+// decimal that it prints as, so that e.g. 0.3 is scaled as 0.3 rather than as
+// the float32 nearest to it, which is slightly more. The table has a
+// column for every unit of scaled capacity, so each extra decimal place
+// multiplies both the runtime and memory of the solve by 10; too few, and
+// items that differ by less than a unit are treated as equal. When T is an
+// integer type the weights and values are used as they are, and `precision`
+// is ignored. The exception is an unsigned weight, value or capacity too
+// large for an int64. Such a capacity is treated as math.MaxInt64, an item
+// that weighs more than that is never packed, and such a value is treated as
+// math.MaxInt64. A capacity more than the total weight of the items is
+// treated as that total, which packs the same items, so that a huge capacity
+// doesn't need a huge table.
+func KnapsackNumber[T Number](items []NumberPackable[T], capacity T, precision int) []int {
+	if capacity < 0 {
+		return []int{}
+	}
+
+	// Halving 1 leaves nothing of an integer, but not of a float.
+	half := T(1)
+	half /= 2
+	isFloat := half != 0
+
+	// Converting 1+1e-10 to a float32 loses the 1e-10, as it can't be held in
+	// a float32's 24 bits.
+	precise := 1 + 1e-10
+	isFloat32 := isFloat && float64(T(precise)) != precise
+
+	var scaled []Packable
+	var scaledCapacity int64
+
+	// positions maps the index of each scaled item back to the index of its
+	// item, if any items had to be left out.
+	var positions []int
+
+	if isFloat {
+		weights := make([]float64, len(items))
+		values := make([]float64, len(items))
+		for i, p := range items {
+			weights[i], values[i] = widen(p.Weight(), isFloat32), widen(p.Value(), isFloat32)
+		}
+		scaled, scaledCapacity = scaleItems(weights, values, widen(capacity, isFloat32), precision)
+	} else {
+		scaledCapacity = saturate(capacity)
+		for i, p := range items {
+			// An item heavier than the capacity can never be packed, and its
+			// weight might not even fit in an int64, so leave it out.
+			if w := p.Weight(); w > 0 && uint64(w) > uint64(scaledCapacity) {
+				continue
+			}
+
+			scaled = append(scaled, item{
+				weight: int64(p.Weight()),
+				value:  saturate(p.Value()),
+			})
+			positions = append(positions, i)
+		}
+		scaledCapacity = boundCapacity(scaled, scaledCapacity)
+	}
+
+	packed := Knapsack(scaled, scaledCapacity)
+	indices := make([]int, len(packed))
+	for i, index := range packed {
+		if positions != nil {
+			indices[i] = positions[index]
+		} else {
+			indices[i] = int(index)
+		}
+	}
+
+	return indices
+}
+
+// widen converts a floating-point `v` to a float64. If `isFloat32` is true,
+// `v` is a float32, and is converted via the shortest decimal that it prints
+// as.
+func widen[T Number](v T, isFloat32 bool) float64 {
+	if !isFloat32 {
+		return float64(v)
+	}
+
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+	return f
+}
+
+// saturate converts an integer `v` to an int64, or to math.MaxInt64 if it's
+// too large to fit, as an unsigned `v` can be.
+func saturate[T Number](v T) int64 {
+	if v > 0 && uint64(v) > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(v)
+}
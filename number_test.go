@@ -0,0 +1,130 @@
+package knapsack
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+type TestNumberItem[T Number] struct {
+	weight T
+	value  T
+}
+
+func (i TestNumberItem[T]) Weight() T {
+	return i.weight
+}
+
+func (i TestNumberItem[T]) Value() T {
+	return i.value
+}
+
+func TestKnapsackNumberInteger(t *testing.T) {
+	items := []NumberPackable[uint16]{
+		TestNumberItem[uint16]{
+			3, 5,
+		},
+		TestNumberItem[uint16]{
+			2, 3,
+		},
+		TestNumberItem[uint16]{
+			1, 4,
+		},
+	}
+
+	// The precision is ignored for integers, so this doesn't build an
+	// enormous table.
+	indices := KnapsackNumber(items, uint16(5), 9)
+	if !reflect.DeepEqual(indices, []int{2, 0}) {
+		t.Errorf("Expected %v, got %v", []int{2, 0}, indices)
+	}
+}
+
+func TestKnapsackNumberUint64(t *testing.T) {
+	items := []NumberPackable[uint64]{
+		TestNumberItem[uint64]{
+			1 << 63, 5,
+		},
+		TestNumberItem[uint64]{
+			2, 3,
+		},
+		TestNumberItem[uint64]{
+			1, 4,
+		},
+	}
+
+	// The first item is too heavy to fit, even into the largest capacity an
+	// int64 can hold.
+	for _, tc := range []struct {
+		capacity uint64
+		expected []int
+	}{
+		{2, []int{2}},
+		{math.MaxUint64, []int{2, 1}},
+	} {
+		if indices := KnapsackNumber(items, tc.capacity, 0); !reflect.DeepEqual(indices, tc.expected) {
+			t.Errorf("Capacity %d: expected %v, got %v", tc.capacity, tc.expected, indices)
+		}
+	}
+
+	// The second item is worth the most, despite its value not fitting in an
+	// int64.
+	items = []NumberPackable[uint64]{
+		TestNumberItem[uint64]{
+			1, 5,
+		},
+		TestNumberItem[uint64]{
+			1, 1 << 63,
+		},
+	}
+	if indices := KnapsackNumber(items, uint64(1), 0); !reflect.DeepEqual(indices, []int{1}) {
+		t.Errorf("Expected %v, got %v", []int{1}, indices)
+	}
+}
+
+func TestKnapsackNumberFloat(t *testing.T) {
+	items := []NumberPackable[float32]{
+		TestNumberItem[float32]{
+			0.3, 5,
+		},
+		TestNumberItem[float32]{
+			0.2, 3,
+		},
+		TestNumberItem[float32]{
+			0.1, 4,
+		},
+	}
+
+	indices := KnapsackNumber(items, float32(0.5), 1)
+	if !reflect.DeepEqual(indices, []int{2, 0}) {
+		t.Errorf("Expected %v, got %v", []int{2, 0}, indices)
+	}
+
+	// With no decimal places, every item weighs a whole unit, which doesn't
+	// fit.
+	indices = KnapsackNumber(items, float32(0.5), 0)
+	if len(indices) != 0 {
+		t.Errorf("Expected no items, got %v", indices)
+	}
+}
+
+func TestKnapsackNumberFloatWithinCapacity(t *testing.T) {
+	items := []NumberPackable[float64]{
+		TestNumberItem[float64]{
+			0.334, 1,
+		},
+		TestNumberItem[float64]{
+			0.334, 1,
+		},
+		TestNumberItem[float64]{
+			0.334, 1,
+		},
+	}
+
+	// Rounded to the nearest hundredth, all three would fit, but they weigh
+	// 1.002 together.
+	indices := KnapsackNumber(items, 1.0, 2)
+	if !reflect.DeepEqual(indices, []int{1, 0}) {
+		t.Errorf("Expected %v, got %v", []int{1, 0}, indices)
+	}
+}
@@ -0,0 +1,71 @@
+package knapsack
+
+// An OnlineKnapsack packs items that arrive one at a time, deciding straight
+// away, and for good, whether to pack each one, without knowing what items
+// are still to come. Create one with NewOnlineKnapsack.
+//
+// Decisions are made with a threshold on value density (value per unit of
+// weight) that rises as the Knapsack fills: an item that fits is packed if
+// its density is at least `2 * used * mean`, where `used` is the fraction of
+// the capacity already packed and `mean` is the mean density of every item
+// offered so far. While the Knapsack is empty anything worth packing is
+// packed, and it becomes increasingly choosy as space runs out. This is a
+// heuristic aimed at a reasonable competitive ratio against the optimal
+// offline solution, and it can do arbitrarily badly on some orderings of
+// items; when all of the items are known up front, use Knapsack instead.
+type OnlineKnapsack struct {
+	capacity  int64
+	remaining int64
+	packed    []Packable
+
+	// offered and densities are the number and total density of the items
+	// with a weight offered so far.
+	offered   int
+	densities float64
+}
+
+// NewOnlineKnapsack creates an empty OnlineKnapsack with the given capacity.
+func NewOnlineKnapsack(capacity int64) *OnlineKnapsack {
+	return &OnlineKnapsack{
+		capacity:  capacity,
+		remaining: capacity,
+	}
+}
+
+// Offer decides whether to pack `item`, returning true if it has been packed.
+// Items without a positive value are never packed, nor are items that don't
+// fit in the remaining capacity. Items without a weight cost nothing to pack,
+// so are always packed if they have a positive value.
+func (k *OnlineKnapsack) Offer(item Packable) bool {
+	w, v := item.Weight(), item.Value()
+	if v <= 0 || w < 0 {
+		return false
+	}
+	if w == 0 {
+		k.packed = append(k.packed, item)
+		return true
+	}
+
+	d := density(item)
+	k.offered++
+	k.densities += d
+
+	if w > k.remaining {
+		return false
+	}
+
+	used := float64(k.capacity-k.remaining) / float64(k.capacity)
+	if d < 2*used*k.densities/float64(k.offered) {
+		return false
+	}
+
+	k.packed = append(k.packed, item)
+	k.remaining -= w
+	return true
+}
+
+// Packed returns the items packed so far, in the order in which they were
+// offered.
+func (k *OnlineKnapsack) Packed() []Packable {
+	return append([]Packable{}, k.packed...)
+}
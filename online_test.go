@@ -0,0 +1,45 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestOnlineKnapsack(t *testing.T) {
+	k := NewOnlineKnapsack(10)
+
+	for i, tc := range []struct {
+		item     Packable
+		expected bool
+	}{
+		// Anything worth packing is packed while the Knapsack is empty.
+		{TestKnapsackItem{5, 5}, true},
+		// Half full, an item needs the mean density of 1.5 to be packed.
+		{TestKnapsackItem{2, 4}, true},
+		// At 70% full, with a mean density of 1.33, an item needs a density
+		// of 1.87, and then, with a mean of 1.625, of 2.275.
+		{TestKnapsackItem{1, 1}, false},
+		{TestKnapsackItem{2, 5}, true},
+		// Items that don't fit, or aren't worth anything, are never packed.
+		{TestKnapsackItem{2, 100}, false},
+		{TestKnapsackItem{1, 0}, false},
+		// Items that weigh nothing are always packed.
+		{TestKnapsackItem{0, 1}, true},
+	} {
+		if packed := k.Offer(tc.item); packed != tc.expected {
+			t.Errorf("Item %d: expected %v, got %v", i, tc.expected, packed)
+		}
+	}
+
+	packed := k.Packed()
+	if len(packed) != 4 {
+		t.Fatalf("Expected 4 items, got %v", packed)
+	}
+
+	var weight int64
+	for _, item := range packed {
+		weight += item.Weight()
+	}
+	if weight != 9 {
+		t.Errorf("Expected a weight of %d, got %d", 9, weight)
+	}
+}
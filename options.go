@@ -0,0 +1,53 @@
+package knapsack
+
+import (
+	"context"
+)
+
+// An Option changes the way that Solve packs a Knapsack.
+type Option func(*config)
+
+// WithContext makes Solve give up and return `ctx.Err()` if `ctx` is canceled
+// or its deadline is exceeded before the Knapsack is packed, as
+// KnapsackContext does. By default, a solve can't be canceled.
+func WithContext(ctx context.Context) Option {
+	return func(cfg *config) {
+		cfg.ctx = ctx
+	}
+}
+
+// WithLogger makes Solve report its progress to `logger`: the dimensions of
+// the DP tables it builds, the maximum value it finds and the number of items
+// in the traceback. By default, nothing is logged.
+func WithLogger(logger Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
+
+// WithMinWeightTieBreak makes Solve prefer the lightest set of items when
+// several are worth the same maximum value, as KnapsackMinWeight does. By
+// default, ties are broken in favour of leaving an item out.
+func WithMinWeightTieBreak() Option {
+	return func(cfg *config) {
+		cfg.tieBreak = tieMinWeight
+	}
+}
+
+// WithOverflowCheck makes Solve return ErrOverflow if the total value of any
+// combination of items overflows an int64, as KnapsackChecked does. By
+// default, values are not checked and silently wrap around.
+func WithOverflowCheck() Option {
+	return func(cfg *config) {
+		cfg.checkOverflow = true
+	}
+}
+
+// WithSortedIndices makes Solve return the indices of the packed items in
+// ascending order, as KnapsackSorted does. By default, they are in the
+// descending order returned by Knapsack.
+func WithSortedIndices() Option {
+	return func(cfg *config) {
+		cfg.sortIndices = true
+	}
+}
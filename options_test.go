@@ -0,0 +1,99 @@
+package knapsack
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSolveWithOptions(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			3, 6,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+	}
+
+	s, err := Solve(items, 5, WithSortedIndices())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i := 1; i < len(s.Indices); i++ {
+		if s.Indices[i-1] >= s.Indices[i] {
+			t.Errorf("Expected ascending indices, got %v", s.Indices)
+		}
+	}
+
+	s, err = Solve(items[:3], 4, WithMinWeightTieBreak())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if s.TotalWeight != 3 {
+		t.Errorf("Expected %d, got %d", 3, s.TotalWeight)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Solve(items, 5, WithContext(ctx)); err != context.Canceled {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+
+	overflowing := []Packable{
+		TestKnapsackItem{
+			1, math.MaxInt64,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+	}
+	if _, err := Solve(overflowing, 2, WithOverflowCheck()); err != ErrOverflow {
+		t.Errorf("Expected %v, got %v", ErrOverflow, err)
+	}
+}
+
+// recordingLogger is a Logger that records every message it's sent.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...any) {
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+func TestSolveWithLogger(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	logger := &recordingLogger{}
+	if _, err := Solve(items, 5, WithLogger(logger)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"knapsack: filling tables of 4 x 6 cells",
+		"knapsack: found a maximum value of 9",
+		"knapsack: traceback found 2 of 3 items",
+	}
+	if !reflect.DeepEqual(logger.messages, expected) {
+		t.Errorf("Expected %q, got %q", expected, logger.messages)
+	}
+}
@@ -0,0 +1,72 @@
+package knapsack
+
+import (
+	"sync"
+)
+
+// KnapsackParallel behaves like Knapsack, but spreads the work of filling each
+// row of the DP table across `workers` goroutines. Every cell in a row only
+// depends on the row before it, so the capacities can be split into
+// contiguous ranges and filled independently, waiting for all of the workers
+// to finish before moving on to the next row.
+//
+// Starting and synchronising the goroutines has a cost for every row, so this
+// is only faster than Knapsack when capacity is large. A `workers` value less
+// than 1 is treated as 1.
+func KnapsackParallel(items []Packable, capacity int64, workers int) []int64 {
+	if len(items) == 0 || capacity < 0 {
+		return []int64{}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	values := make([][]int64, len(items)+1)
+	keep := make([][]bool, len(items)+1)
+	for i := range values {
+		values[i] = make([]int64, capacity+1)
+		keep[i] = make([]bool, capacity+1)
+	}
+
+	// Round the size of each worker's range up, so that every capacity is
+	// covered.
+	span := (capacity + int64(workers)) / int64(workers)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= len(items); i++ {
+		w, v := items[i-1].Weight(), items[i-1].Value()
+
+		for start := int64(0); start <= capacity; start += span {
+			end := start + span - 1
+			if end > capacity {
+				end = capacity
+			}
+
+			wg.Add(1)
+			go func(i int, start, end int64) {
+				defer wg.Done()
+
+				for c := start; c <= end; c++ {
+					values[i][c] = values[i-1][c]
+					if w <= c && v+values[i-1][c-w] > values[i][c] {
+						values[i][c] = v + values[i-1][c-w]
+						keep[i][c] = true
+					}
+				}
+			}(i, start, end)
+		}
+
+		wg.Wait()
+	}
+
+	c := capacity
+	indices := []int64{}
+	for n := len(items); n > 0; n-- {
+		if keep[n][c] {
+			indices = append(indices, int64(n-1))
+			c -= items[n-1].Weight()
+		}
+	}
+
+	return indices
+}
@@ -0,0 +1,52 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackParallel(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			4, 6,
+		},
+	}
+
+	for _, workers := range []int{0, 1, 2, 3, 16} {
+		for _, capacity := range []int64{1, 5, 7, 10} {
+			expected := Knapsack(items, capacity)
+			indices := KnapsackParallel(items, capacity, workers)
+
+			if len(indices) != len(expected) {
+				t.Fatalf("Expected %v, got %v", expected, indices)
+			}
+			for i := range expected {
+				if indices[i] != expected[i] {
+					t.Errorf("Expected %v, got %v", expected, indices)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkKnapsack(b *testing.B) {
+	items := RandomItems(100, 1000, 1000, 1)
+	for n := 0; n < b.N; n++ {
+		Knapsack(items, 200000)
+	}
+}
+
+func BenchmarkKnapsackParallel(b *testing.B) {
+	items := RandomItems(100, 1000, 1000, 1)
+	for n := 0; n < b.N; n++ {
+		KnapsackParallel(items, 200000, 4)
+	}
+}
@@ -0,0 +1,34 @@
+package knapsack
+
+// ParetoFrontier returns every trade-off between weight and value worth
+// making when packing items into a Knapsack of up to the given capacity: a
+// Solution for each set of items that no other set beats by being both no
+// heavier and more valuable. The Solutions are ordered by ascending weight,
+// and so by ascending value, with the last being the lightest set worth the
+// maximum value. That's worth as much as the Solution returned by Solve, but
+// may not be the same items, since Solve doesn't look for the lightest set.
+// Each Solution's RemainingCapacity is measured against `capacity`.
+//
+// The frontier falls out of the last row of Knapsack's DP table, which holds
+// the maximum value that fits within each capacity from 0 up to `capacity`.
+// Wherever that value increases, the items that achieve it must weigh exactly
+// that capacity, and can't be matched by anything lighter.
+func ParetoFrontier(items []Packable, capacity int64) []Solution {
+	if capacity < 0 {
+		return []Solution{}
+	}
+
+	weights, itemValues := snapshot(items)
+	values, keep, _ := fill(defaultConfig(), weights, itemValues, capacity)
+	best := values[len(items)]
+
+	frontier := []Solution{}
+	for c := int64(0); c <= capacity; c++ {
+		if c == 0 || best[c] > best[c-1] {
+			indices := traceback(weights, keep, c)
+			frontier = append(frontier, newSolution(items, indices, capacity))
+		}
+	}
+
+	return frontier
+}
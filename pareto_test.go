@@ -0,0 +1,48 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestParetoFrontier(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	frontier := ParetoFrontier(items, 5)
+
+	// The frontier steps through (0, 0), (1, 4), (3, 7) and (4, 9). Packing
+	// the first two items gives (5, 8), which is dominated by (4, 9).
+	expected := [][2]int64{{0, 0}, {1, 4}, {3, 7}, {4, 9}}
+	if len(frontier) != len(expected) {
+		t.Fatalf("Expected %d solutions, got %v", len(expected), frontier)
+	}
+	for i, s := range frontier {
+		if s.TotalWeight != expected[i][0] || s.TotalValue != expected[i][1] {
+			t.Errorf("Solution %d: expected weight %d and value %d, got %v", i, expected[i][0], expected[i][1], s)
+		}
+		if s.RemainingCapacity != 5-s.TotalWeight {
+			t.Errorf("Solution %d: expected %d remaining, got %d", i, 5-s.TotalWeight, s.RemainingCapacity)
+		}
+	}
+
+	last := frontier[len(frontier)-1]
+	if s, _ := Solve(items, 5); s.TotalValue != last.TotalValue {
+		t.Errorf("Expected the last solution to be worth %d, got %d", s.TotalValue, last.TotalValue)
+	}
+}
+
+func TestParetoFrontierNoItems(t *testing.T) {
+	frontier := ParetoFrontier(nil, 5)
+	if len(frontier) != 1 || frontier[0].TotalValue != 0 || frontier[0].TotalWeight != 0 {
+		t.Errorf("Expected a single empty solution, got %v", frontier)
+	}
+}
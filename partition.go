@@ -0,0 +1,42 @@
+package knapsack
+
+// BalancedPartition splits `weights` into two groups whose sums are as close
+// to each other as possible, returning the indices of the weights in each
+// group in ascending order. Weights are assumed to be non-negative.
+//
+// The lighter group can weigh at most half of the total, so this finds the
+// largest sum up to half of the total that some subset of the weights can
+// reach, using the same table as SubsetSum, and puts every other weight in
+// the second group. This takes O(N x total) time, and O(N x total) bits of
+// memory.
+func BalancedPartition(weights []int64) ([]int64, []int64) {
+	var total int64 = 0
+	for _, w := range weights {
+		total += w
+	}
+
+	half := total / 2
+	reachable := sumTable(weights, half)
+
+	// A sum of zero is always reachable, with the empty subset.
+	s := half
+	for reachable[len(weights)].Bit(int(s)) == 0 {
+		s--
+	}
+
+	inFirst := make([]bool, len(weights))
+	for _, i := range subsetFor(weights, reachable, s) {
+		inFirst[i] = true
+	}
+
+	first, second := []int64{}, []int64{}
+	for i := range weights {
+		if inFirst[i] {
+			first = append(first, int64(i))
+		} else {
+			second = append(second, int64(i))
+		}
+	}
+
+	return first, second
+}
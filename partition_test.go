@@ -0,0 +1,38 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestBalancedPartition(t *testing.T) {
+	weights := []int64{1, 6, 11, 5}
+
+	first, second := BalancedPartition(weights)
+	if len(first)+len(second) != len(weights) {
+		t.Fatalf("Expected every weight in a group, got %v and %v", first, second)
+	}
+
+	var a, b int64 = 0, 0
+	for _, i := range first {
+		a += weights[i]
+	}
+	for _, i := range second {
+		b += weights[i]
+	}
+
+	if diff := b - a; diff != 1 {
+		t.Errorf("Expected a difference of %d, got %d", 1, diff)
+	}
+}
+
+func TestBalancedPartitionEdgeCases(t *testing.T) {
+	first, second := BalancedPartition([]int64{})
+	if first == nil || second == nil || len(first) != 0 || len(second) != 0 {
+		t.Errorf("Expected two empty groups, got %v and %v", first, second)
+	}
+
+	first, second = BalancedPartition([]int64{7})
+	if len(first) != 0 || len(second) != 1 || second[0] != 0 {
+		t.Errorf("Expected the weight in the second group, got %v and %v", first, second)
+	}
+}
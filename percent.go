@@ -0,0 +1,25 @@
+package knapsack
+
+import (
+	"math"
+)
+
+// KnapsackPercent behaves like Knapsack, but takes the capacity as a
+// percentage of the total weight of all of the items, rather than as an
+// absolute weight. The capacity is `percent / 100` of the total weight,
+// rounded to the nearest integer with halves rounded to even, as ScaleToInt
+// rounds, so 0 packs only items that weigh nothing and 100 packs every item
+// worth packing. ErrInvalidPercent is returned unless 0 <= percent <= 100.
+func KnapsackPercent(items []Packable, percent float64) ([]int64, error) {
+	if !(percent >= 0 && percent <= 100) {
+		return nil, ErrInvalidPercent
+	}
+
+	var total int64
+	for _, item := range items {
+		total += item.Weight()
+	}
+
+	capacity := int64(math.RoundToEven(percent / 100 * float64(total)))
+	return Knapsack(items, capacity), nil
+}
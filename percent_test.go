@@ -0,0 +1,59 @@
+package knapsack
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackPercent(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			0, 1,
+		},
+		TestKnapsackItem{
+			4, 0,
+		},
+	}
+
+	// The items weigh 10 in total.
+	for _, tc := range []struct {
+		percent  float64
+		expected []int64
+	}{
+		// Only the item that weighs nothing fits.
+		{0, []int64{3}},
+		// 45% of 10 rounds to even, giving a capacity of 4.
+		{45, []int64{3, 2, 0}},
+		{50, []int64{3, 2, 0}},
+		{60, []int64{3, 2, 1, 0}},
+		// Everything fits, but the last item isn't worth packing.
+		{100, []int64{3, 2, 1, 0}},
+	} {
+		indices, err := KnapsackPercent(items, tc.percent)
+		if err != nil {
+			t.Errorf("%v%%: expected no error, got %v", tc.percent, err)
+			continue
+		}
+		if !reflect.DeepEqual(indices, tc.expected) {
+			t.Errorf("%v%%: expected %v, got %v", tc.percent, tc.expected, indices)
+		}
+	}
+}
+
+func TestKnapsackPercentInvalid(t *testing.T) {
+	for _, percent := range []float64{-1, 100.5, math.NaN()} {
+		if _, err := KnapsackPercent(nil, percent); err != ErrInvalidPercent {
+			t.Errorf("%v%%: expected %v, got %v", percent, ErrInvalidPercent, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package knapsack
+
+// QuadraticKnapsack packs items that are worth more (or less) when packed
+// together. On top of each item's own value, `synergy[i][j]` is added for
+// every pair of packed items with `i < j`; entries on or below the diagonal
+// are ignored. It returns the indices of the items to pack, in ascending
+// order.
+//
+// The quadratic knapsack problem is NP-hard and the DP used by Knapsack
+// doesn't extend to it, so this is a local search heuristic. It starts by
+// greedily packing whichever item adds the most value per unit of weight
+// given the items already packed, and then repeatedly packs, removes or swaps
+// single items while doing so improves the total value. Each round of the
+// search takes O(N^3) time. The result is always a valid packing, but is only
+// guaranteed to be locally optimal.
+//
+// ErrLengthMismatch is returned unless `synergy` is an N x N matrix for N
+// items.
+func QuadraticKnapsack(items []Packable, synergy [][]int64, capacity int64) ([]int64, error) {
+	if len(synergy) != len(items) {
+		return nil, ErrLengthMismatch
+	}
+	for _, row := range synergy {
+		if len(row) != len(items) {
+			return nil, ErrLengthMismatch
+		}
+	}
+
+	q := quadratic{
+		items:   items,
+		synergy: synergy,
+		packed:  make([]bool, len(items)),
+	}
+	remaining := capacity
+
+	// Greedily pack the item with the best gain per unit of weight until no
+	// item that fits adds any value.
+	for {
+		best := -1
+		var bestGain, bestWeight int64
+		for i, item := range items {
+			w := item.Weight()
+			if q.packed[i] || w < 0 || w > remaining {
+				continue
+			}
+
+			gain := q.gain(i)
+			if gain <= 0 {
+				continue
+			}
+			if best == -1 || gain*bestWeight > bestGain*w {
+				best, bestGain, bestWeight = i, gain, w
+			}
+		}
+
+		if best == -1 {
+			break
+		}
+		q.packed[best] = true
+		remaining -= bestWeight
+	}
+
+	// Then improve on the greedy packing until no single move helps.
+	for improved := true; improved; {
+		improved = false
+
+		for i := range items {
+			w := items[i].Weight()
+
+			switch {
+			case q.packed[i] && q.gain(i) < 0:
+				// Removing an item that costs more than it adds.
+				q.packed[i] = false
+				remaining += w
+				improved = true
+
+			case !q.packed[i] && w >= 0 && w <= remaining && q.gain(i) > 0:
+				q.packed[i] = true
+				remaining -= w
+				improved = true
+
+			case q.packed[i]:
+				// Swapping a packed item for one that isn't.
+				q.packed[i] = false
+				swapped := false
+				for j := range items {
+					wj := items[j].Weight()
+					if j == i || q.packed[j] || wj < 0 || wj > remaining+w {
+						continue
+					}
+					if q.gain(j) > q.gain(i) {
+						q.packed[j] = true
+						remaining += w - wj
+						swapped = true
+						break
+					}
+				}
+				if swapped {
+					improved = true
+				} else {
+					q.packed[i] = true
+				}
+			}
+		}
+	}
+
+	indices := []int64{}
+	for i, packed := range q.packed {
+		if packed {
+			indices = append(indices, int64(i))
+		}
+	}
+	return indices, nil
+}
+
+// quadratic holds the state of a QuadraticKnapsack search.
+type quadratic struct {
+	items   []Packable
+	synergy [][]int64
+	packed  []bool
+}
+
+// gain returns the value that item `i` adds to the packed items other than
+// itself, including its synergy with each of them.
+func (q quadratic) gain(i int) int64 {
+	gain := q.items[i].Value()
+	for j, packed := range q.packed {
+		switch {
+		case !packed || j == i:
+		case j < i:
+			gain += q.synergy[j][i]
+		default:
+			gain += q.synergy[i][j]
+		}
+	}
+	return gain
+}
@@ -0,0 +1,156 @@
+package knapsack
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestQuadraticKnapsack(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 5,
+		},
+	}
+
+	// On their own, the third item is the best to pack alongside either of
+	// the others, but the first two are worth far more together.
+	synergy := [][]int64{
+		{0, 10, 0},
+		{0, 0, 0},
+		{0, 0, 0},
+	}
+
+	indices, err := QuadraticKnapsack(items, synergy, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []int64{0, 1}
+	if len(indices) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, indices)
+	}
+	for i := range expected {
+		if indices[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, indices)
+		}
+	}
+}
+
+func TestQuadraticKnapsackInvalidSynergy(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+	}
+
+	for _, synergy := range [][][]int64{{{0, 1}}, {{0, 1}, {0}}} {
+		if _, err := QuadraticKnapsack(items, synergy, 4); err != ErrLengthMismatch {
+			t.Errorf("Expected %v, got %v", ErrLengthMismatch, err)
+		}
+	}
+}
+
+func TestQuadraticKnapsackKeepsSwappedOutItem(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			1, 10,
+		},
+		TestKnapsackItem{
+			10, 50,
+		},
+	}
+
+	// Greedily both items are packed, and then the first is removed as it
+	// costs more than it adds. The second must stay packed, even though it
+	// can't be swapped for anything better.
+	synergy := [][]int64{
+		{0, -20},
+		{0, 0},
+	}
+
+	indices, err := QuadraticKnapsack(items, synergy, 20)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(indices) != 1 || indices[0] != 1 {
+		t.Errorf("Expected %v, got %v", []int64{1}, indices)
+	}
+}
+
+func TestQuadraticKnapsackLocallyOptimal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 500; n++ {
+		items, capacity := randomProblem(r)
+		synergy := make([][]int64, len(items))
+		for i := range synergy {
+			synergy[i] = make([]int64, len(items))
+			for j := i + 1; j < len(items); j++ {
+				synergy[i][j] = r.Int63n(21) - 10
+			}
+		}
+
+		indices, err := QuadraticKnapsack(items, synergy, capacity)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		packed := make([]bool, len(items))
+		for _, i := range indices {
+			packed[i] = true
+		}
+		weight, value := quadraticTotals(items, synergy, packed)
+		if weight > capacity && len(indices) > 0 {
+			t.Fatalf("%v in %d: packed %v weighing %d", items, capacity, indices, weight)
+		}
+
+		// No single item can be packed, removed or swapped for another to
+		// gain value within the capacity.
+		for i := range items {
+			packed[i] = !packed[i]
+			if w, v := quadraticTotals(items, synergy, packed); w <= capacity && v > value {
+				t.Errorf("%v in %d: packed %v worth %d, but flipping %d is worth %d", items, capacity, indices, value, i, v)
+			}
+
+			for j := range items {
+				if !packed[i] && packed[j] || packed[i] && !packed[j] {
+					continue
+				}
+				packed[j] = !packed[j]
+				if w, v := quadraticTotals(items, synergy, packed); w <= capacity && v > value {
+					t.Errorf("%v in %d: packed %v worth %d, but swapping %d and %d is worth %d", items, capacity, indices, value, i, j, v)
+				}
+				packed[j] = !packed[j]
+			}
+			packed[i] = !packed[i]
+		}
+	}
+}
+
+// quadraticTotals returns the total weight and value of the items that are
+// packed, including the synergy between each pair of them.
+func quadraticTotals(items []Packable, synergy [][]int64, packed []bool) (int64, int64) {
+	var weight, value int64
+	for i, item := range items {
+		if !packed[i] {
+			continue
+		}
+		weight += item.Weight()
+		value += item.Value()
+		for j := i + 1; j < len(items); j++ {
+			if packed[j] {
+				value += synergy[i][j]
+			}
+		}
+	}
+	return weight, value
+}
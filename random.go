@@ -0,0 +1,24 @@
+package knapsack
+
+import (
+	"math/rand"
+)
+
+// RandomItems generates `n` items with weights between 1 and `maxWeight` and
+// values between 1 and `maxValue`, inclusive, for use in tests and
+// benchmarks. The items are generated from a rand.Rand seeded with `seed`, so
+// the same arguments always produce the same items. Both `maxWeight` and
+// `maxValue` must be positive.
+func RandomItems(n int, maxWeight, maxValue int64, seed int64) []Packable {
+	r := rand.New(rand.NewSource(seed))
+
+	items := make([]Packable, n)
+	for i := range items {
+		items[i] = item{
+			weight: r.Int63n(maxWeight) + 1,
+			value:  r.Int63n(maxValue) + 1,
+		}
+	}
+
+	return items
+}
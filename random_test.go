@@ -0,0 +1,28 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestRandomItems(t *testing.T) {
+	items := RandomItems(100, 10, 20, 1)
+	if len(items) != 100 {
+		t.Fatalf("Expected %d items, got %d", 100, len(items))
+	}
+
+	for _, item := range items {
+		if item.Weight() < 1 || item.Weight() > 10 {
+			t.Errorf("Expected a weight between 1 and 10, got %d", item.Weight())
+		}
+		if item.Value() < 1 || item.Value() > 20 {
+			t.Errorf("Expected a value between 1 and 20, got %d", item.Value())
+		}
+	}
+
+	again := RandomItems(100, 10, 20, 1)
+	for i := range items {
+		if items[i] != again[i] {
+			t.Fatalf("Expected the same items for the same seed, got %v and %v", items[i], again[i])
+		}
+	}
+}
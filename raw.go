@@ -0,0 +1,21 @@
+package knapsack
+
+// KnapsackRaw behaves like Knapsack, but takes the weights and values of the
+// items as two parallel slices, so that item `i` weighs `weights[i]` and is
+// worth `values[i]`, saving callers from having to implement Packable.
+// ErrLengthMismatch is returned if the slices aren't the same length.
+func KnapsackRaw(weights []int64, values []int64, capacity int64) ([]int64, error) {
+	if len(weights) != len(values) {
+		return nil, ErrLengthMismatch
+	}
+
+	items := make([]Packable, len(weights))
+	for i := range items {
+		items[i] = item{
+			weight: weights[i],
+			value:  values[i],
+		}
+	}
+
+	return Knapsack(items, capacity), nil
+}
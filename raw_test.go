@@ -0,0 +1,24 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackRaw(t *testing.T) {
+	indices, err := KnapsackRaw([]int64{3, 2, 1}, []int64{5, 3, 4}, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []int64{2, 0}
+	if !reflect.DeepEqual(indices, expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+}
+
+func TestKnapsackRawLengthMismatch(t *testing.T) {
+	if _, err := KnapsackRaw([]int64{3, 2}, []int64{5}, 5); err != ErrLengthMismatch {
+		t.Errorf("Expected %v, got %v", ErrLengthMismatch, err)
+	}
+}
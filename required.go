@@ -0,0 +1,55 @@
+package knapsack
+
+import (
+	"sort"
+)
+
+// KnapsackRequired behaves like Knapsack, except that the items at the
+// `required` indices are always packed, whether or not an optimal solution
+// would include them. The remaining capacity is then packed as valuably as
+// possible with the other items. It returns the indices of every packed item,
+// required or not, in ascending order.
+//
+// ErrIndexOutOfRange is returned if a required index doesn't refer to an
+// item, and ErrRequiredExceedsCapacity if the required items don't fit within
+// capacity on their own.
+func KnapsackRequired(items []Packable, required []int64, capacity int64) ([]int64, error) {
+	isRequired := make([]bool, len(items))
+	remaining := capacity
+	for _, i := range required {
+		if i < 0 || i >= int64(len(items)) {
+			return nil, ErrIndexOutOfRange
+		}
+		if !isRequired[i] {
+			isRequired[i] = true
+			remaining -= items[i].Weight()
+		}
+	}
+
+	if remaining < 0 {
+		return nil, ErrRequiredExceedsCapacity
+	}
+
+	// Solve for the optional items in the capacity left over, keeping track
+	// of where each of them came from.
+	var optional []Packable
+	var original []int64
+	indices := []int64{}
+	for i, item := range items {
+		if isRequired[i] {
+			indices = append(indices, int64(i))
+			continue
+		}
+		optional = append(optional, item)
+		original = append(original, int64(i))
+	}
+
+	for _, i := range Knapsack(optional, remaining) {
+		indices = append(indices, original[i])
+	}
+
+	sort.Slice(indices, func(a, b int) bool {
+		return indices[a] < indices[b]
+	})
+	return indices, nil
+}
@@ -0,0 +1,55 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackRequired(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	// The optimal solution is the first and third items, but the second must
+	// be packed, leaving room for only the third.
+	indices, err := KnapsackRequired(items, []int64{1}, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []int64{1, 2}
+	if len(indices) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, indices)
+	}
+	for i := range expected {
+		if indices[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, indices)
+		}
+	}
+}
+
+func TestKnapsackRequiredExceedsCapacity(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+	}
+
+	if _, err := KnapsackRequired(items, []int64{0, 1}, 4); err != ErrRequiredExceedsCapacity {
+		t.Errorf("Expected %v, got %v", ErrRequiredExceedsCapacity, err)
+	}
+
+	if _, err := KnapsackRequired(items, []int64{2}, 4); err != ErrIndexOutOfRange {
+		t.Errorf("Expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+}
@@ -0,0 +1,25 @@
+package knapsack
+
+// KnapsackWithSetup behaves like Knapsack, except that packing item `i` incurs
+// a fixed cost of `setupCost[i]`, so the item is only worth
+// `items[i].Value() - setupCost[i]`. Items whose setup cost leaves them with
+// no positive value are never packed. It returns the indices of the items to
+// pack.
+//
+// ErrLengthMismatch is returned if `setupCost` does not have one entry per
+// item.
+func KnapsackWithSetup(items []Packable, setupCost []int64, capacity int64) ([]int64, error) {
+	if len(setupCost) != len(items) {
+		return nil, ErrLengthMismatch
+	}
+
+	effective := make([]Packable, len(items))
+	for i, p := range items {
+		effective[i] = item{
+			weight: p.Weight(),
+			value:  p.Value() - setupCost[i],
+		}
+	}
+
+	return Knapsack(effective, capacity), nil
+}
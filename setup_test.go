@@ -0,0 +1,48 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestKnapsackWithSetup(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	// The setup cost of the first item makes it worthless, leaving the second
+	// and third.
+	indices, err := KnapsackWithSetup(items, []int64{5, 1, 0}, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []int64{2, 1}
+	if len(indices) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, indices)
+	}
+	for i := range expected {
+		if indices[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, indices)
+		}
+	}
+}
+
+func TestKnapsackWithSetupLengthMismatch(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+	}
+
+	if _, err := KnapsackWithSetup(items, []int64{}, 5); err != ErrLengthMismatch {
+		t.Errorf("Expected %v, got %v", ErrLengthMismatch, err)
+	}
+}
@@ -0,0 +1,170 @@
+package knapsack
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// A Solution describes a packed Knapsack.
+type Solution struct {
+	// Indices holds the indices of the packed items.
+	Indices []int64
+
+	// TotalValue and TotalWeight are the sums of the values and weights of the
+	// packed items.
+	TotalValue  int64
+	TotalWeight int64
+
+	// RemainingCapacity is the capacity left unused by the packed items. It's
+	// never negative, so for a negative capacity, in which nothing is packed,
+	// it's 0.
+	RemainingCapacity int64
+}
+
+// String describes the Solution on a single line for logging and debugging,
+// e.g. `Solution{items: [0 2 5], value: 120, weight: 18/20 (90%)}`, where the
+// weight is shown against the capacity along with the percentage used.
+func (s Solution) String() string {
+	capacity := s.TotalWeight + s.RemainingCapacity
+
+	var used float64
+	if capacity > 0 {
+		used = 100 * float64(s.TotalWeight) / float64(capacity)
+	}
+
+	return fmt.Sprintf("Solution{items: %v, value: %d, weight: %d/%d (%.0f%%)}",
+		s.Indices, s.TotalValue, s.TotalWeight, capacity, used)
+}
+
+// solutionJSON is the wire format of a Solution. It's kept separate from
+// Solution so that the field names stay stable, and in snake_case for the
+// benefit of non-Go consumers, regardless of changes to the Go names.
+type solutionJSON struct {
+	Indices           []int64 `json:"indices"`
+	TotalValue        int64   `json:"total_value"`
+	TotalWeight       int64   `json:"total_weight"`
+	RemainingCapacity int64   `json:"remaining_capacity"`
+}
+
+// MarshalJSON encodes the Solution as a JSON object with the fields
+// `indices`, `total_value`, `total_weight` and `remaining_capacity`. A
+// Solution with no indices is encoded with an empty array rather than null.
+func (s Solution) MarshalJSON() ([]byte, error) {
+	indices := s.Indices
+	if indices == nil {
+		indices = []int64{}
+	}
+
+	return json.Marshal(solutionJSON{
+		Indices:           indices,
+		TotalValue:        s.TotalValue,
+		TotalWeight:       s.TotalWeight,
+		RemainingCapacity: s.RemainingCapacity,
+	})
+}
+
+// UnmarshalJSON decodes a Solution from the format written by MarshalJSON.
+func (s *Solution) UnmarshalJSON(data []byte) error {
+	var v solutionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*s = Solution{
+		Indices:           v.Indices,
+		TotalValue:        v.TotalValue,
+		TotalWeight:       v.TotalWeight,
+		RemainingCapacity: v.RemainingCapacity,
+	}
+	return nil
+}
+
+// Solve packs items into a Knapsack of the given capacity just like Knapsack,
+// but returns a Solution describing the result rather than just the indices
+// of the packed items. The way the items are packed can be changed with
+// `opts`; with no options, the Solution packs the same items as Knapsack and
+// the error is always nil.
+func Solve(items []Packable, capacity int64, opts ...Option) (Solution, error) {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	indices, _, err := knapsack(cfg, items, capacity)
+	if err != nil {
+		return Solution{}, err
+	}
+	return newSolution(items, indices, capacity), nil
+}
+
+// An ItemContribution records how much a packed item adds to a Solution.
+type ItemContribution struct {
+	// Index is the index of the packed item.
+	Index int64
+
+	// Weight and Value are the item's share of the Solution's TotalWeight and
+	// TotalValue.
+	Weight int64
+	Value  int64
+}
+
+// SolveDetailed packs items into a Knapsack of the given capacity just like
+// Knapsack, but returns a breakdown of what each packed item contributes to
+// the total weight and value. Only packed items are included, ordered by
+// descending value; items of equal value are ordered by index.
+func SolveDetailed(items []Packable, capacity int64) []ItemContribution {
+	contributions := []ItemContribution{}
+	for _, i := range KnapsackSorted(items, capacity) {
+		contributions = append(contributions, ItemContribution{
+			Index:  i,
+			Weight: items[i].Weight(),
+			Value:  items[i].Value(),
+		})
+	}
+
+	sort.SliceStable(contributions, func(a, b int) bool {
+		return contributions[a].Value > contributions[b].Value
+	})
+
+	return contributions
+}
+
+// newSolution builds a Solution for the items at `indices`. The totals are
+// always calculated from the items themselves so that they're consistent with
+// the indices.
+func newSolution(items []Packable, indices []int64, capacity int64) Solution {
+	s := Solution{
+		Indices: indices,
+	}
+	for _, i := range indices {
+		s.TotalValue += items[i].Value()
+		s.TotalWeight += items[i].Weight()
+	}
+	if capacity > s.TotalWeight {
+		s.RemainingCapacity = capacity - s.TotalWeight
+	}
+
+	return s
+}
+
+// KnapsackRemaining behaves like Knapsack but also returns the capacity left
+// unused once the items have been packed. The remaining capacity is worked out
+// from the weights of the returned items, so it always agrees with them, and
+// it's never negative, even for a negative capacity.
+func KnapsackRemaining(items []Packable, capacity int64) ([]int64, int64) {
+	s, _ := Solve(items, capacity)
+	return s.Indices, s.RemainingCapacity
+}
+
+// Utilization returns the fraction (0.0 - 1.0) of capacity used by the items
+// that Knapsack packs. A Knapsack with no capacity has nothing to use, so its
+// utilization is 0.
+func Utilization(items []Packable, capacity int64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+
+	s, _ := Solve(items, capacity)
+	return float64(s.TotalWeight) / float64(capacity)
+}
@@ -0,0 +1,200 @@
+package knapsack
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	s, err := Solve(items, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(s.Indices) != 3 {
+		t.Errorf("Expected 3 items, got %v", s.Indices)
+	}
+	if s.TotalValue != 12 {
+		t.Errorf("Expected %d, got %d", 12, s.TotalValue)
+	}
+	if s.TotalWeight != 6 {
+		t.Errorf("Expected %d, got %d", 6, s.TotalWeight)
+	}
+	if s.RemainingCapacity != 1 {
+		t.Errorf("Expected %d, got %d", 1, s.RemainingCapacity)
+	}
+}
+
+func TestSolveNoItems(t *testing.T) {
+	s, err := Solve([]Packable{}, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(s.Indices) != 0 || s.TotalValue != 0 || s.TotalWeight != 0 {
+		t.Errorf("Expected an empty solution, got %+v", s)
+	}
+	if s.RemainingCapacity != 7 {
+		t.Errorf("Expected %d, got %d", 7, s.RemainingCapacity)
+	}
+}
+
+func TestKnapsackRemaining(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	for _, capacity := range []int64{0, 1, 4, 5, 10} {
+		indices, remaining := KnapsackRemaining(items, capacity)
+
+		var weight int64 = 0
+		for _, i := range indices {
+			weight += items[i].Weight()
+		}
+
+		if remaining != capacity-weight {
+			t.Errorf("Expected %d, got %d", capacity-weight, remaining)
+		}
+		if remaining < 0 {
+			t.Errorf("Expected a non-negative remaining capacity, got %d", remaining)
+		}
+	}
+
+	// Nothing fits into a negative capacity, and nothing is left over.
+	indices, remaining := KnapsackRemaining(items, -5)
+	if len(indices) != 0 || remaining != 0 {
+		t.Errorf("Expected nothing packed and %d remaining, got %v and %d", 0, indices, remaining)
+	}
+	if s, _ := Solve(items, -5); s.String() != "Solution{items: [], value: 0, weight: 0/0 (0%)}" {
+		t.Errorf("Unexpected %v", s)
+	}
+}
+
+func TestUtilization(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	if u := Utilization(items, 8); u != 0.75 {
+		t.Errorf("Expected %f, got %f", 0.75, u)
+	}
+	if u := Utilization(items, 5); u != 0.8 {
+		t.Errorf("Expected %f, got %f", 0.8, u)
+	}
+	if u := Utilization(items, 0); u != 0 {
+		t.Errorf("Expected %f, got %f", 0.0, u)
+	}
+}
+
+func TestSolutionJSON(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	s, err := Solve(items, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := `{"indices":[2,0],"total_value":9,"total_weight":4,"remaining_capacity":1}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded Solution
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(decoded, s) {
+		t.Errorf("Expected %+v, got %+v", s, decoded)
+	}
+}
+
+func TestSolutionString(t *testing.T) {
+	s := Solution{
+		Indices:           []int64{0, 2, 5},
+		TotalValue:        120,
+		TotalWeight:       18,
+		RemainingCapacity: 2,
+	}
+
+	expected := "Solution{items: [0 2 5], value: 120, weight: 18/20 (90%)}"
+	if got := s.String(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	expected = "Solution{items: [], value: 0, weight: 0/0 (0%)}"
+	if got := (Solution{}).String(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestSolveDetailed(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 4,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			1, 5,
+		},
+	}
+
+	expected := []ItemContribution{
+		{Index: 3, Weight: 1, Value: 5},
+		{Index: 0, Weight: 3, Value: 4},
+		{Index: 2, Weight: 1, Value: 4},
+	}
+	if contributions := SolveDetailed(items, 5); !reflect.DeepEqual(contributions, expected) {
+		t.Errorf("Expected %v, got %v", expected, contributions)
+	}
+
+	if contributions := SolveDetailed(items, 0); contributions == nil || len(contributions) != 0 {
+		t.Errorf("Expected an empty slice, got %#v", contributions)
+	}
+}
@@ -0,0 +1,144 @@
+package knapsack
+
+import (
+	"sync"
+)
+
+// A Solver holds on to the DP tables built for a set of items, so that the
+// solution can be updated cheaply as more items are added, and so that the
+// same items can be packed into Knapsacks of many different capacities
+// without building the tables again. The maximum capacity is fixed when the
+// Solver is created with NewSolver.
+//
+// A Solver is safe for concurrent use by multiple goroutines. Any number of
+// calls to Value and Indices can run at once, while AddItem waits for them to
+// finish and holds them off until the new item has been added. Clone can be
+// called alongside Value and Indices, and the clone shares nothing with the
+// original, so the two can be used independently.
+type Solver struct {
+	// mu guards every other field, which only AddItem changes.
+	mu sync.RWMutex
+
+	items    []Packable
+	capacity int64
+
+	// weights holds the weight of every item, as it was when the item was
+	// added.
+	weights []int64
+
+	// values and keep are the matrices built by Knapsack, with a row for every
+	// item added so far.
+	values [][]int64
+	keep   [][]int
+}
+
+// NewSolver creates a Solver for packing `items` into a Knapsack of any
+// capacity up to `maxCapacity`, building the DP tables for the items straight
+// away. With a negative `maxCapacity` there are no capacities to query, and
+// AddItem never packs anything.
+func NewSolver(items []Packable, maxCapacity int64) *Solver {
+	// The tables have no columns at all for a negative capacity, whatever it
+	// is.
+	if maxCapacity < 0 {
+		maxCapacity = -1
+	}
+
+	weights, itemValues := snapshot(items)
+	values, keep, _ := fill(defaultConfig(), weights, itemValues, maxCapacity)
+
+	return &Solver{
+		items:    append([]Packable(nil), items...),
+		capacity: maxCapacity,
+		weights:  weights,
+		values:   values,
+		keep:     keep,
+	}
+}
+
+// Value returns the maximum value that can be packed into a Knapsack with a
+// capacity of `c`, in O(1) time. `c` must be between 0 and the maximum
+// capacity of the Solver.
+func (s *Solver) Value(c int64) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.values[len(s.items)][c]
+}
+
+// Indices returns the indices of the items to pack into a Knapsack with a
+// capacity of `c`, as Knapsack would, in O(N) time. `c` must be between 0 and
+// the maximum capacity of the Solver.
+func (s *Solver) Indices(c int64) []int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.indices(c)
+}
+
+// indices does the work of Indices for callers that already hold the lock.
+func (s *Solver) indices(c int64) []int64 {
+	if c < 0 {
+		return []int64{}
+	}
+	return traceback(s.weights, s.keep, c)
+}
+
+// AddItem adds an item to the Solver and returns the indices of the items to
+// pack into a Knapsack of the Solver's maximum capacity now that it's
+// available, as Knapsack would for every item added so far. The new item's
+// index is the number of items added before it.
+//
+// Adding an item doesn't change the DP rows for the items before it, so only
+// a single new row of the tables needs to be calculated, taking O(M) time
+// rather than the O(N x M) of solving again from scratch.
+func (s *Solver) AddItem(item Packable) []int64 {
+	w, v := item.Weight(), item.Value()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = append(s.items, item)
+	s.weights = append(s.weights, w)
+
+	previous := s.values[len(s.values)-1]
+	values := make([]int64, s.capacity+1)
+	keep := make([]int, s.capacity+1)
+
+	for c := int64(0); c <= s.capacity; c++ {
+		values[c] = previous[c]
+		if w <= c && v+previous[c-w] > previous[c] {
+			values[c] = v + previous[c-w]
+			keep[c] = 1
+		}
+	}
+
+	s.values = append(s.values, values)
+	s.keep = append(s.keep, keep)
+
+	return s.indices(s.capacity)
+}
+
+// Clone returns a copy of the Solver that can be changed, e.g. by AddItem,
+// without affecting the original, so that "what if" scenarios can be explored
+// from a common starting point. The DP tables are copied in full, so cloning
+// takes O(N x M) time and memory.
+func (s *Solver) Clone() *Solver {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clone := &Solver{
+		items:    append([]Packable(nil), s.items...),
+		capacity: s.capacity,
+		weights:  append([]int64(nil), s.weights...),
+		values:   make([][]int64, len(s.values)),
+		keep:     make([][]int, len(s.keep)),
+	}
+	for i := range s.values {
+		clone.values[i] = append([]int64(nil), s.values[i]...)
+	}
+	for i := range s.keep {
+		clone.keep[i] = append([]int(nil), s.keep[i]...)
+	}
+
+	return clone
+}
@@ -0,0 +1,197 @@
+package knapsack
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSolverAddItem(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			4, 8,
+		},
+	}
+
+	s := NewSolver(items[:1], 5)
+	for n := 2; n <= len(items); n++ {
+		indices := s.AddItem(items[n-1])
+		expected := Knapsack(items[:n], 5)
+
+		if len(indices) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, indices)
+		}
+		for i := range expected {
+			if indices[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, indices)
+			}
+		}
+	}
+}
+
+func TestSolverAddItemToEmpty(t *testing.T) {
+	s := NewSolver(nil, 5)
+
+	indices := s.AddItem(TestKnapsackItem{3, 5})
+	if len(indices) != 1 || indices[0] != 0 {
+		t.Errorf("Expected %v, got %v", []int64{0}, indices)
+	}
+}
+
+func TestSolverCapacities(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			4, 8,
+		},
+	}
+
+	s := NewSolver(items, 10)
+	for c := int64(0); c <= 10; c++ {
+		expected, value := KnapsackWithValue(items, c)
+
+		if v := s.Value(c); v != value {
+			t.Errorf("Expected %d, got %d", value, v)
+		}
+
+		indices := s.Indices(c)
+		if len(indices) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, indices)
+		}
+		for i := range expected {
+			if indices[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, indices)
+			}
+		}
+	}
+}
+
+func TestSolverClone(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	base := NewSolver(items[:2], 5)
+	before := base.Value(5)
+
+	// Branch two different scenarios from the same base.
+	a := base.Clone()
+	a.AddItem(items[2])
+	b := base.Clone()
+	b.AddItem(TestKnapsackItem{5, 20})
+
+	if a.Value(5) != 9 {
+		t.Errorf("Expected %d, got %d", 9, a.Value(5))
+	}
+	if b.Value(5) != 20 {
+		t.Errorf("Expected %d, got %d", 20, b.Value(5))
+	}
+	if indices := b.Indices(5); len(indices) != 1 || indices[0] != 2 {
+		t.Errorf("Expected %v, got %v", []int64{2}, indices)
+	}
+	if base.Value(5) != before || len(base.Indices(5)) != 2 {
+		t.Errorf("Expected the base Solver to be unchanged, got value %d and %v", base.Value(5), base.Indices(5))
+	}
+}
+
+func TestSolverConcurrentQueries(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			4, 8,
+		},
+	}
+
+	const capacity = 10
+	expectedIndices := make([][]int64, capacity+1)
+	expectedValues := make([]int64, capacity+1)
+	for c := int64(0); c <= capacity; c++ {
+		expectedIndices[c], expectedValues[c] = KnapsackWithValue(items, c)
+	}
+
+	s := NewSolver(items, capacity)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for n := 0; n < 200; n++ {
+				c := int64((g + n) % (capacity + 1))
+				if v := s.Value(c); v != expectedValues[c] {
+					t.Errorf("Capacity %d: expected %d, got %d", c, expectedValues[c], v)
+				}
+				if indices := s.Indices(c); !reflect.DeepEqual(indices, expectedIndices[c]) {
+					t.Errorf("Capacity %d: expected %v, got %v", c, expectedIndices[c], indices)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestSolverConcurrentAddItem(t *testing.T) {
+	s := NewSolver(nil, 10)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 50; n++ {
+			s.AddItem(TestKnapsackItem{int64(n%4 + 1), int64(n%5 + 1)})
+		}
+	}()
+
+	// Adding an item never makes the best value worse, so every reader must
+	// see the value at each capacity stay the same or grow.
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var previous int64
+			for n := 0; n < 200; n++ {
+				v := s.Value(10)
+				if v < previous {
+					t.Errorf("Expected at least %d, got %d", previous, v)
+				}
+				previous = v
+				s.Indices(10)
+				s.Clone()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,75 @@
+package knapsack
+
+// KnapsackSparse behaves exactly like Knapsack, returning the same indices,
+// but only stores the total weights that some set of items can actually reach,
+// rather than a cell for every capacity from 0 to `capacity`. When the weights
+// are large and few in number, far fewer weights are reachable than there are
+// capacities, so this uses much less memory, and lets a Knapsack with a huge
+// capacity be solved at all.
+//
+// For each item, a map holds the best value of the first items for every
+// total weight they can reach without exceeding the capacity. The best value
+// within a capacity, which Knapsack stores directly, is then the best value
+// of any reachable weight no greater than it. Time and memory are both
+// O(N x S), where S is the number of reachable weights.
+func KnapsackSparse(items []Packable, capacity int64) []int64 {
+	if len(items) == 0 || capacity < 0 {
+		return []int64{}
+	}
+
+	weights, itemValues := snapshot(items)
+
+	// `reachable[i]` maps every total weight reachable with the first `i`
+	// items to the best value of the items that reach it.
+	reachable := make([]map[int64]int64, len(items)+1)
+	reachable[0] = map[int64]int64{0: 0}
+
+	for i := 1; i <= len(items); i++ {
+		w, v := weights[i-1], itemValues[i-1]
+		previous := reachable[i-1]
+
+		next := make(map[int64]int64, len(previous))
+		for total, value := range previous {
+			next[total] = value
+		}
+		for total, value := range previous {
+			// Compare against the room left rather than adding the weight on,
+			// which could overflow.
+			if w > capacity-total {
+				continue
+			}
+			if best, ok := next[total+w]; !ok || value+v > best {
+				next[total+w] = value + v
+			}
+		}
+
+		reachable[i] = next
+	}
+
+	// Trace back making the same decisions as Knapsack: an item is kept if
+	// packing it is worth strictly more than leaving it out.
+	indices := []int64{}
+	c := capacity
+	for n := len(items); n > 0; n-- {
+		w, v := weights[n-1], itemValues[n-1]
+		if w <= c && bestWithin(reachable[n-1], c-w)+v > bestWithin(reachable[n-1], c) {
+			indices = append(indices, int64(n-1))
+			c -= w
+		}
+	}
+
+	return indices
+}
+
+// bestWithin returns the best value of any total weight in `reachable` that
+// doesn't exceed `capacity`.
+func bestWithin(reachable map[int64]int64, capacity int64) int64 {
+	var best int64
+	for total, value := range reachable {
+		if total <= capacity && value > best {
+			best = value
+		}
+	}
+
+	return best
+}
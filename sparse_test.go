@@ -0,0 +1,90 @@
+package knapsack
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackSparse(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			0, 2,
+		},
+	}
+
+	for capacity := int64(0); capacity <= 9; capacity++ {
+		expected := Knapsack(items, capacity)
+		if indices := KnapsackSparse(items, capacity); !reflect.DeepEqual(indices, expected) {
+			t.Errorf("Capacity %d: expected %v, got %v", capacity, expected, indices)
+		}
+	}
+}
+
+func TestKnapsackSparseRandom(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		items := RandomItems(15, 20, 10, seed)
+
+		expected := Knapsack(items, 100)
+		if indices := KnapsackSparse(items, 100); !reflect.DeepEqual(indices, expected) {
+			t.Errorf("Seed %d: expected %v, got %v", seed, expected, indices)
+		}
+	}
+}
+
+func TestKnapsackSparseHugeCapacity(t *testing.T) {
+	const unit = 1 << 40
+	items := []Packable{
+		TestKnapsackItem{
+			3 * unit, 5,
+		},
+		TestKnapsackItem{
+			2 * unit, 3,
+		},
+		TestKnapsackItem{
+			1 * unit, 4,
+		},
+	}
+
+	indices := KnapsackSparse(items, 5*unit)
+	if !reflect.DeepEqual(indices, []int64{2, 0}) {
+		t.Errorf("Expected %v, got %v", []int64{2, 0}, indices)
+	}
+}
+
+func TestKnapsackSparseHeavyItem(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			5, 3,
+		},
+		TestKnapsackItem{
+			math.MaxInt64, 100,
+		},
+		TestKnapsackItem{
+			6, 1,
+		},
+	}
+
+	// Adding the second item's weight to any other overflows.
+	expected := []int64{0}
+	if indices := KnapsackSparse(items, 10); !reflect.DeepEqual(indices, expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+
+	// KnapsackAuto packs so few items into this capacity with KnapsackSparse.
+	if indices := KnapsackAuto(items, 10); !reflect.DeepEqual(indices, expected) {
+		t.Errorf("Expected %v, got %v", expected, indices)
+	}
+}
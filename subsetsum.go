@@ -0,0 +1,98 @@
+package knapsack
+
+import (
+	"math/big"
+)
+
+// SubsetSum finds a subset of `weights` that adds up to exactly `target`,
+// returning the indices of the weights in the subset and true, or false if no
+// such subset exists. This is the special case of the Knapsack problem where
+// every item is worth its weight and we only want to know whether the
+// capacity can be filled exactly, so rather than a table of values it only
+// needs a table of which sums are reachable, built as in ReachableSums.
+// Weights are assumed to be non-negative.
+func SubsetSum(weights []int64, target int64) ([]int64, bool) {
+	if target < 0 {
+		return nil, false
+	}
+
+	reachable := sumTable(weights, target)
+	if reachable[len(weights)].Bit(int(target)) == 0 {
+		return nil, false
+	}
+
+	return subsetFor(weights, reachable, target), true
+}
+
+// ReachableSums returns a bitset of the sums, up to `capacity`, that some
+// subset of `weights` adds up to exactly: bit `s` of the result is set if and
+// only if there is such a subset for `s`. Bit 0 is always set for the empty
+// subset, unless `capacity` is negative. Weights are assumed to be
+// non-negative.
+//
+// Rather than checking each sum in turn, the sums reachable with each weight
+// are found by shifting the sums reachable without it along by the weight and
+// combining the two, which works on a whole machine word of sums at a time.
+func ReachableSums(weights []int64, capacity int64) *big.Int {
+	if capacity < 0 {
+		return new(big.Int)
+	}
+
+	mask := sumMask(capacity)
+	sums := big.NewInt(1)
+	for _, w := range weights {
+		sums = addWeight(sums, w, capacity, mask)
+	}
+
+	return sums
+}
+
+// sumTable builds a table in which bit `s` of `reachable[i]` records whether
+// some subset of the first `i` weights adds up to `s`, for every `s` up to
+// `limit`.
+func sumTable(weights []int64, limit int64) []*big.Int {
+	mask := sumMask(limit)
+	reachable := make([]*big.Int, len(weights)+1)
+	reachable[0] = big.NewInt(1)
+
+	for i, w := range weights {
+		reachable[i+1] = addWeight(reachable[i], w, limit, mask)
+	}
+
+	return reachable
+}
+
+// sumMask returns a bitset of every sum from 0 to `limit`.
+func sumMask(limit int64) *big.Int {
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(limit+1))
+	return mask.Sub(mask, big.NewInt(1))
+}
+
+// addWeight returns the sums up to `limit` that are reachable by adding `w`
+// to any of `sums`, or by leaving it out. `mask` must be sumMask(limit).
+func addWeight(sums *big.Int, w int64, limit int64, mask *big.Int) *big.Int {
+	next := new(big.Int).Set(sums)
+	if w < 0 || w > limit {
+		return next
+	}
+
+	shifted := new(big.Int).Lsh(sums, uint(w))
+	next.Or(next, shifted)
+	return next.And(next, mask)
+}
+
+// subsetFor returns the indices of a subset of `weights` that adds up to `s`,
+// which must be reachable according to the table built by sumTable.
+func subsetFor(weights []int64, reachable []*big.Int, s int64) []int64 {
+	// If the sum was reachable without weight `i`, leave it out, otherwise it
+	// must be in the subset.
+	indices := []int64{}
+	for n := len(weights); n > 0; n-- {
+		if reachable[n-1].Bit(int(s)) == 0 {
+			indices = append(indices, int64(n-1))
+			s -= weights[n-1]
+		}
+	}
+
+	return indices
+}
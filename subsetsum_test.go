@@ -0,0 +1,60 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestSubsetSum(t *testing.T) {
+	weights := []int64{3, 34, 4, 12, 5, 2}
+
+	indices, ok := SubsetSum(weights, 9)
+	if !ok {
+		t.Fatalf("Expected a subset summing to %d", 9)
+	}
+
+	var sum int64 = 0
+	for _, i := range indices {
+		sum += weights[i]
+	}
+	if sum != 9 {
+		t.Errorf("Expected %d, got %d", 9, sum)
+	}
+
+	if _, ok := SubsetSum(weights, 30); ok {
+		t.Errorf("Expected no subset summing to %d", 30)
+	}
+
+	if indices, ok := SubsetSum(weights, 0); !ok || len(indices) != 0 {
+		t.Errorf("Expected the empty subset, got %v", indices)
+	}
+}
+
+func TestReachableSums(t *testing.T) {
+	weights := []int64{3, 5, 0, 20}
+
+	// The subsets of the first two weights reach 0, 3, 5 and 8; the weight of
+	// 0 adds nothing, and 20 is beyond the capacity.
+	sums := ReachableSums(weights, 10)
+	if sums.Int64() != 1<<0|1<<3|1<<5|1<<8 {
+		t.Errorf("Expected %b, got %b", 1<<0|1<<3|1<<5|1<<8, sums)
+	}
+
+	if sums := ReachableSums(nil, 10); sums.Int64() != 1 {
+		t.Errorf("Expected %b, got %b", 1, sums)
+	}
+	if sums := ReachableSums(weights, -1); sums.Sign() != 0 {
+		t.Errorf("Expected no sums, got %b", sums)
+	}
+}
+
+func TestReachableSumsMatchesSubsetSum(t *testing.T) {
+	weights := []int64{7, 13, 4, 29, 11, 2, 40}
+	sums := ReachableSums(weights, 110)
+
+	for s := int64(0); s <= 110; s++ {
+		_, ok := SubsetSum(weights, s)
+		if reachable := sums.Bit(int(s)) == 1; reachable != ok {
+			t.Errorf("Sum %d: expected %v, got %v", s, ok, reachable)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package knapsack
+
+// ValueOf returns the total value of the items at `indices`, such as those
+// returned by Knapsack. It returns ErrIndexOutOfRange if any index doesn't
+// refer to one of `items`.
+func ValueOf(items []Packable, indices []int64) (int64, error) {
+	return sumOf(items, indices, Packable.Value)
+}
+
+// WeightOf returns the total weight of the items at `indices`. Like ValueOf,
+// it returns ErrIndexOutOfRange if any index doesn't refer to one of `items`.
+func WeightOf(items []Packable, indices []int64) (int64, error) {
+	return sumOf(items, indices, Packable.Weight)
+}
+
+// sumOf adds up `f` over the items at `indices`.
+func sumOf(items []Packable, indices []int64, f func(Packable) int64) (int64, error) {
+	var total int64
+	for _, i := range indices {
+		if i < 0 || i >= int64(len(items)) {
+			return 0, ErrIndexOutOfRange
+		}
+		total += f(items[i])
+	}
+
+	return total, nil
+}
@@ -0,0 +1,50 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestValueOfAndWeightOf(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	value, err := ValueOf(items, []int64{0, 2})
+	if err != nil || value != 9 {
+		t.Errorf("Expected %d, got %d (%v)", 9, value, err)
+	}
+
+	weight, err := WeightOf(items, []int64{0, 2})
+	if err != nil || weight != 4 {
+		t.Errorf("Expected %d, got %d (%v)", 4, weight, err)
+	}
+
+	if value, err := ValueOf(items, nil); err != nil || value != 0 {
+		t.Errorf("Expected %d, got %d (%v)", 0, value, err)
+	}
+}
+
+func TestValueOfOutOfRange(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+	}
+
+	for _, indices := range [][]int64{{1}, {-1}, {0, 3}} {
+		if _, err := ValueOf(items, indices); err != ErrIndexOutOfRange {
+			t.Errorf("%v: Expected %v, got %v", indices, ErrIndexOutOfRange, err)
+		}
+		if _, err := WeightOf(items, indices); err != ErrIndexOutOfRange {
+			t.Errorf("%v: Expected %v, got %v", indices, ErrIndexOutOfRange, err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package knapsack
+
+// UnboundedKnapsack solves the unbounded variant of the Knapsack problem, in
+// which any number of copies of each item may be packed. It returns a map of
+// item index to the number of copies of that item to pack; items that should
+// not be packed are left out of the map.
+//
+// An item with a positive value and no weight would let us pack an infinite
+// amount of value, so ErrZeroWeightItem is returned if one is found.
+func UnboundedKnapsack(items []Packable, capacity int64) (map[int64]int64, error) {
+	for _, item := range items {
+		if item.Weight() <= 0 && item.Value() > 0 {
+			return nil, ErrZeroWeightItem
+		}
+	}
+
+	if capacity < 0 {
+		return map[int64]int64{}, nil
+	}
+
+	// Because items can be reused, we no longer need a row per item. `values[c]`
+	// stores the best value for a capacity of `c`, and `last[c]` stores the index
+	// of the last item added to reach it, or -1 if no item was added.
+	values := make([]int64, capacity+1)
+	last := make([]int64, capacity+1)
+	for c := range last {
+		last[c] = -1
+	}
+
+	for c := int64(1); c <= capacity; c++ {
+		for i, item := range items {
+			w := item.Weight()
+			if w <= 0 || w > c {
+				continue
+			}
+
+			// Unlike the 0/1 problem, we draw on the value for the remaining
+			// capacity in the current "row", which may already include this item.
+			if v := item.Value() + values[c-w]; v > values[c] {
+				values[c] = v
+				last[c] = int64(i)
+			}
+		}
+	}
+
+	// Walk back through `last`, counting each item as we remove its weight from
+	// the capacity.
+	counts := make(map[int64]int64)
+	for c := capacity; c > 0 && last[c] != -1; {
+		i := last[c]
+		counts[i]++
+		c -= items[i].Weight()
+	}
+
+	return counts, nil
+}
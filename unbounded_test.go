@@ -0,0 +1,52 @@
+package knapsack
+
+import (
+	"testing"
+)
+
+func TestUnboundedKnapsack(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 1,
+		},
+	}
+
+	counts, err := UnboundedKnapsack(items, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var value, weight int64 = 0, 0
+	for i, n := range counts {
+		value += items[i].Value() * n
+		weight += items[i].Weight() * n
+	}
+
+	if value != 11 {
+		t.Errorf("Expected %d, got %d", 11, value)
+	}
+	if weight > 7 {
+		t.Errorf("Expected a weight of at most %d, got %d", 7, weight)
+	}
+}
+
+func TestUnboundedKnapsackZeroWeight(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			0, 1,
+		},
+	}
+
+	if _, err := UnboundedKnapsack(items, 7); err != ErrZeroWeightItem {
+		t.Errorf("Expected %v, got %v", ErrZeroWeightItem, err)
+	}
+}
@@ -0,0 +1,77 @@
+package knapsack
+
+import (
+	"container/heap"
+)
+
+// UnboundedGreedy approximates the unbounded Knapsack problem solved by
+// UnboundedKnapsack, in which any number of copies of each item may be
+// packed. It returns a map of item index to the number of copies of that item
+// to pack; items that should not be packed are left out of the map.
+//
+// Items are taken from a priority queue in order of descending value density,
+// and as many copies of each as still fit are packed. This takes O(N log N)
+// time whatever the capacity, so it suits huge capacities, or finding a good
+// starting point for an exact search, but it isn't optimal: packing a denser
+// item can leave capacity that a less dense one would have filled. Its value
+// is always at least half of the optimum, since the copies of the densest
+// item alone fill at least half of the capacity. Items without a
+// positive value, or without a positive weight, are never packed.
+func UnboundedGreedy(items []Packable, capacity int64) map[int64]int64 {
+	queue := &densityQueue{
+		items: items,
+	}
+	for i, item := range items {
+		if item.Weight() > 0 && item.Weight() <= capacity && item.Value() > 0 {
+			queue.indices = append(queue.indices, int64(i))
+		}
+	}
+	heap.Init(queue)
+
+	copies := make(map[int64]int64)
+	remaining := capacity
+	for queue.Len() > 0 {
+		i := heap.Pop(queue).(int64)
+		if n := remaining / items[i].Weight(); n > 0 {
+			copies[i] = n
+			remaining -= n * items[i].Weight()
+		}
+	}
+
+	return copies
+}
+
+// densityQueue is a heap.Interface of the indices of items, ordered so that
+// the item with the greatest value density comes first, and among items of
+// equal density, the one with the lowest index.
+type densityQueue struct {
+	items   []Packable
+	indices []int64
+}
+
+func (q *densityQueue) Len() int {
+	return len(q.indices)
+}
+
+func (q *densityQueue) Less(a, b int) bool {
+	da, db := density(q.items[q.indices[a]]), density(q.items[q.indices[b]])
+	if da != db {
+		return da > db
+	}
+	return q.indices[a] < q.indices[b]
+}
+
+func (q *densityQueue) Swap(a, b int) {
+	q.indices[a], q.indices[b] = q.indices[b], q.indices[a]
+}
+
+func (q *densityQueue) Push(x any) {
+	q.indices = append(q.indices, x.(int64))
+}
+
+func (q *densityQueue) Pop() any {
+	n := len(q.indices)
+	i := q.indices[n-1]
+	q.indices = q.indices[:n-1]
+	return i
+}
@@ -0,0 +1,57 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnboundedGreedy(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			4, 8,
+		},
+	}
+
+	// The densest item goes first, and the rest of the capacity is filled
+	// with the next densest.
+	expected := map[int64]int64{2: 2, 0: 1}
+	if copies := UnboundedGreedy(items, 11); !reflect.DeepEqual(copies, expected) {
+		t.Errorf("Expected %v, got %v", expected, copies)
+	}
+}
+
+func TestUnboundedGreedyAgainstExact(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		items := RandomItems(8, 15, 30, seed)
+
+		for _, capacity := range []int64{0, 10, 37, 100} {
+			exact, err := UnboundedKnapsack(items, capacity)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			greedy := UnboundedGreedy(items, capacity)
+
+			var optimal, value, weight int64
+			for i, n := range exact {
+				optimal += n * items[i].Value()
+			}
+			for i, n := range greedy {
+				value += n * items[i].Value()
+				weight += n * items[i].Weight()
+			}
+
+			if weight > capacity {
+				t.Errorf("Seed %d, capacity %d: expected a weight of at most %d, got %d", seed, capacity, capacity, weight)
+			}
+			if value > optimal || 2*value < optimal {
+				t.Errorf("Seed %d, capacity %d: expected between %d and %d, got %d", seed, capacity, (optimal+1)/2, optimal, value)
+			}
+		}
+	}
+}
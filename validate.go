@@ -0,0 +1,60 @@
+package knapsack
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// maxAlloc is the most memory, in bytes, that the Go runtime can allocate:
+// 2^48 bytes on 64 bit platforms and 2^32 on 32 bit ones. Asking for more
+// panics rather than returning an error.
+const maxAlloc = 1 << (32 + 16*(bits.UintSize/64))
+
+// An ItemError records an error caused by a specific item.
+type ItemError struct {
+	// Index is the index of the item that caused the error.
+	Index int64
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%v (item %d)", e.Err, e.Index)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks that a set of items and a capacity are suitable for
+// Knapsack, which assumes that neither the capacity nor any item's weight is
+// negative. It returns ErrNegativeCapacity if `capacity` is negative,
+// ErrCapacityTooLarge if the DP tables for the items and `capacity`, as sized
+// by EstimateMemory, are more than can be allocated on this platform, or an
+// *ItemError for the first item that is nil (wrapping ErrNilItem) or has a
+// negative weight (wrapping ErrNegativeWeight), so that callers can check
+// their input before solving.
+func Validate(items []Packable, capacity int64) error {
+	if capacity < 0 {
+		return ErrNegativeCapacity
+	}
+	if EstimateMemory(len(items), capacity) > maxAlloc {
+		return ErrCapacityTooLarge
+	}
+
+	for i, item := range items {
+		switch {
+		case item == nil:
+			return &ItemError{
+				Index: int64(i),
+				Err:   ErrNilItem,
+			}
+		case item.Weight() < 0:
+			return &ItemError{
+				Index: int64(i),
+				Err:   ErrNegativeWeight,
+			}
+		}
+	}
+
+	return nil
+}
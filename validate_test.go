@@ -0,0 +1,96 @@
+package knapsack
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			-1, 4,
+		},
+	}
+
+	if err := Validate(items[:2], 5); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if err := Validate(items[:2], -1); err != ErrNegativeCapacity {
+		t.Errorf("Expected %v, got %v", ErrNegativeCapacity, err)
+	}
+
+	err := Validate(items, 5)
+	if !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected %v, got %v", ErrNegativeWeight, err)
+	}
+
+	var itemErr *ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 2 {
+		t.Errorf("Expected an error for item %d, got %v", 2, err)
+	}
+}
+
+func TestValidateNilItem(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		nil,
+	}
+
+	err := Validate(items, 5)
+	if !errors.Is(err, ErrNilItem) {
+		t.Errorf("Expected %v, got %v", ErrNilItem, err)
+	}
+
+	var itemErr *ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 1 {
+		t.Errorf("Expected an error for item %d, got %v", 1, err)
+	}
+
+	if _, err := KnapsackChecked(items, 5); !errors.Is(err, ErrNilItem) {
+		t.Errorf("Expected %v, got %v", ErrNilItem, err)
+	}
+}
+
+func TestValidateCapacityTooLarge(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+	}
+
+	// Each row of the tables for 1<<40 fits, but not all of them together.
+	many := make([]Packable, 300)
+	for i := range many {
+		many[i] = items[0]
+	}
+
+	for _, tc := range []struct {
+		items    []Packable
+		capacity int64
+	}{
+		{items, math.MaxInt64},
+		{items, 1 << 62},
+		{many, 1 << 40},
+	} {
+		if err := Validate(tc.items, tc.capacity); err != ErrCapacityTooLarge {
+			t.Errorf("%d: expected %v, got %v", tc.capacity, ErrCapacityTooLarge, err)
+		}
+		if _, err := KnapsackChecked(tc.items, tc.capacity); err != ErrCapacityTooLarge {
+			t.Errorf("%d: expected %v, got %v", tc.capacity, ErrCapacityTooLarge, err)
+		}
+	}
+
+	if err := Validate(items, 1<<20); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
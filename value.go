@@ -0,0 +1,61 @@
+package knapsack
+
+// KnapsackValueOnly calculates the maximum value to be gained from an array of
+// items whilst keeping the total weight less than or equal to a capacity, but
+// doesn't determine which items to pack.
+//
+// Knapsack has to hold on to every row of its tables so it can trace back the
+// items it kept, using O(N x M) memory. If all we're after is the value, each
+// row only depends on the one before it, so we can get away with a single row
+// of M+1 values that we overwrite as we go, needing only O(M) memory.
+func KnapsackValueOnly(items []Packable, capacity int64) int64 {
+	if capacity < 0 {
+		return 0
+	}
+	return valueRow(items, capacity)[capacity]
+}
+
+// KnapsackSweep returns the maximum value that can be packed into a Knapsack
+// of every capacity from 0 to `capacity`, so that index `c` of the result
+// holds the optimal value for a capacity of `c`, such as for plotting how the
+// value grows with capacity. Only the values are returned, not the items that
+// make them up. This is the last row of the table returned by KnapsackTable,
+// but like KnapsackValueOnly it only needs O(M) memory to calculate. There
+// are no capacities up to a negative one, so an empty slice is returned.
+func KnapsackSweep(items []Packable, capacity int64) []int64 {
+	return valueRow(items, capacity)
+}
+
+// valueRow returns the last row of Knapsack's `values` matrix, holding the
+// maximum value within every capacity up to `capacity`, using a single row.
+func valueRow(items []Packable, capacity int64) []int64 {
+	if capacity < 0 {
+		return []int64{}
+	}
+
+	values := make([]int64, capacity+1)
+
+	for _, item := range items {
+		w, v := item.Weight(), item.Value()
+
+		// Iterate over the capacities in reverse, so that `values[c-w]` still
+		// holds the value from the previous row when we read it. Going forwards
+		// would allow an item to be packed more than once.
+		for c := capacity; c >= w; c-- {
+			if v+values[c-w] > values[c] {
+				values[c] = v + values[c-w]
+			}
+		}
+	}
+
+	return values
+}
+
+// MaxValue returns the maximum value that can be packed into a Knapsack of
+// the given capacity, exactly as KnapsackTable would report it at
+// `table[len(items)][capacity]`, without building the `keep` matrix or
+// tracing back the items. It's an alias for KnapsackValueOnly, under the name
+// that's easier to find, and so needs only O(M) memory.
+func MaxValue(items []Packable, capacity int64) int64 {
+	return KnapsackValueOnly(items, capacity)
+}
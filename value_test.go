@@ -0,0 +1,64 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackValueOnly(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	for _, capacity := range []int64{0, 1, 2, 3, 4, 5, 6, 10} {
+		_, expected := KnapsackWithValue(items, capacity)
+		if value := KnapsackValueOnly(items, capacity); value != expected {
+			t.Errorf("Expected %d, got %d", expected, value)
+		}
+	}
+}
+
+func TestMaxValue(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		items := RandomItems(20, 15, 30, seed)
+		table := KnapsackTable(items, 50)
+
+		for _, capacity := range []int64{0, 1, 10, 50} {
+			if value := MaxValue(items, capacity); value != table[len(items)][capacity] {
+				t.Errorf("Seed %d, capacity %d: expected %d, got %d", seed, capacity, table[len(items)][capacity], value)
+			}
+		}
+	}
+}
+
+func TestKnapsackSweep(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+	}
+
+	expected := []int64{0, 4, 4, 7, 9, 9, 12, 12}
+	if values := KnapsackSweep(items, 7); !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+
+	table := KnapsackTable(items, 7)
+	if !reflect.DeepEqual(table[len(items)], expected) {
+		t.Errorf("Expected the last row of the table, %v, got %v", table[len(items)], expected)
+	}
+}
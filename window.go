@@ -0,0 +1,81 @@
+package knapsack
+
+import (
+	"math"
+)
+
+// KnapsackWeightWindow packs items so as to maximise their total value while
+// keeping their total weight between `minWeight` and `maxWeight`, inclusive,
+// rather than just below a capacity. It returns the indices of the items to
+// pack, in the same order as Knapsack. When several sets of items are worth
+// the same maximum value, the lightest is returned. Item weights must not be
+// negative.
+//
+// Knapsack's table holds the best value within each capacity, which says
+// nothing about how little the items weigh. Instead, `values[i][w]` stores the
+// maximum value of a set of the first `i` items weighing exactly `w`, or
+// math.MinInt64 if no set weighs exactly `w`, which takes O(N x maxWeight)
+// time and memory.
+//
+// ErrInfeasible is returned if no set of items has a total weight within the
+// window, including when `minWeight` is greater than `maxWeight`.
+func KnapsackWeightWindow(items []Packable, minWeight, maxWeight int64) ([]int64, error) {
+	if minWeight < 0 {
+		minWeight = 0
+	}
+	if minWeight > maxWeight {
+		return nil, ErrInfeasible
+	}
+
+	values := make([][]int64, len(items)+1)
+	keep := make([][]bool, len(items)+1)
+	for i := range values {
+		values[i] = make([]int64, maxWeight+1)
+		keep[i] = make([]bool, maxWeight+1)
+	}
+	for w := int64(1); w <= maxWeight; w++ {
+		values[0][w] = math.MinInt64
+	}
+
+	for i := 1; i <= len(items); i++ {
+		iw, iv := items[i-1].Weight(), items[i-1].Value()
+
+		for w := int64(0); w <= maxWeight; w++ {
+			values[i][w] = values[i-1][w]
+
+			// Packing this item brings a set weighing `w-iw` up to `w`, if
+			// there is one.
+			if iw > w || values[i-1][w-iw] == math.MinInt64 {
+				continue
+			}
+
+			if taken := iv + values[i-1][w-iw]; values[i][w] == math.MinInt64 || taken > values[i][w] {
+				values[i][w] = taken
+				keep[i][w] = true
+			}
+		}
+	}
+
+	// Scanning upwards from `minWeight` finds the lightest of the best sets.
+	best := int64(-1)
+	for w := minWeight; w <= maxWeight; w++ {
+		v := values[len(items)][w]
+		if v != math.MinInt64 && (best < 0 || v > values[len(items)][best]) {
+			best = w
+		}
+	}
+	if best < 0 {
+		return nil, ErrInfeasible
+	}
+
+	w := best
+	indices := []int64{}
+	for n := len(items); n > 0; n-- {
+		if keep[n][w] {
+			indices = append(indices, int64(n-1))
+			w -= items[n-1].Weight()
+		}
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,63 @@
+package knapsack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnapsackWeightWindow(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			2, 3,
+		},
+		TestKnapsackItem{
+			1, 4,
+		},
+		TestKnapsackItem{
+			4, 1,
+		},
+	}
+
+	for _, tc := range []struct {
+		minWeight, maxWeight int64
+		expected             []int64
+	}{
+		// With no minimum, this is just Knapsack.
+		{0, 5, []int64{2, 0}},
+		// The best set weighs 4, which is also the only weight allowed.
+		{4, 4, []int64{2, 0}},
+		// Otherwise the next best set that weighs enough has to do.
+		{5, 5, []int64{1, 0}},
+		// Only packing everything weighs 10.
+		{10, 10, []int64{3, 2, 1, 0}},
+	} {
+		indices, err := KnapsackWeightWindow(items, tc.minWeight, tc.maxWeight)
+		if err != nil {
+			t.Errorf("[%d, %d]: Expected no error, got %v", tc.minWeight, tc.maxWeight, err)
+			continue
+		}
+		if !reflect.DeepEqual(indices, tc.expected) {
+			t.Errorf("[%d, %d]: Expected %v, got %v", tc.minWeight, tc.maxWeight, tc.expected, indices)
+		}
+	}
+}
+
+func TestKnapsackWeightWindowInfeasible(t *testing.T) {
+	items := []Packable{
+		TestKnapsackItem{
+			3, 5,
+		},
+		TestKnapsackItem{
+			3, 3,
+		},
+	}
+
+	for _, window := range [][2]int64{{4, 5}, {7, 10}, {5, 4}} {
+		if _, err := KnapsackWeightWindow(items, window[0], window[1]); err != ErrInfeasible {
+			t.Errorf("%v: Expected %v, got %v", window, ErrInfeasible, err)
+		}
+	}
+}